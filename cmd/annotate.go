@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var gateName string
+var gateResultName string
+var gateResult expr.GateResult
+var pipelineURLEnv string
+
+// annotateCmd represents the annotate command
+var annotateCmd = &cobra.Command{
+	Use:   "annotate experiment-name",
+	Short: "Record the decision of a CI/CD gate on an Iter8 experiment",
+	Long: `Record the decision of a CI/CD gate, such as a pipeline step which runs 'iter8ctl assert', as an annotation on the experiment, so that the decision is persisted along with the experiment. The annotation records the name of the gate, its result, the time of the decision, and the URL of the pipeline run, which is read from the environment variable named by --pipeline-url-env. A later decision of the same gate replaces the earlier one. Recorded decisions are shown in the gate history section of 'iter8ctl describe'.
+
+The annotation key is ` + expr.GateAnnotationPrefix + `<gate>, and its value is a JSON object with result, time, and pipelineRun fields.`,
+	Example: `  iter8ctl annotate my-experiment -n my-namespace --gate canary-gate --gate-result passed
+  iter8ctl annotate my-experiment -n my-namespace --gate canary-gate --gate-result failed --pipeline-url-env CI_PIPELINE_URL`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return expr.NewError(expr.KindUsage, "exactly one experiment name must be supplied")
+		}
+		if expName = args[0]; expName == "" {
+			return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+		}
+		if err := expr.ValidateGateName(gateName); err != nil {
+			return usageError(err)
+		}
+		var err error
+		if gateResult, err = expr.ParseGateResult(gateResultName); err != nil {
+			return usageError(err)
+		}
+		// get experiment from cluster
+		if exp, err = expr.GetExperiment(false, expName, expNamespace); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := expr.GateDecision{
+			Gate:        gateName,
+			Result:      gateResult,
+			Time:        metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+			PipelineRun: os.Getenv(pipelineURLEnv),
+		}
+		if err := expr.AnnotateGateDecision(context.Background(), exp.Namespace, exp.Name, d); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Recorded gate %s %s for experiment %s/%s\n", d.Gate, d.Result, exp.Namespace, exp.Name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(annotateCmd)
+	annotateCmd.Flags().StringVar(&gateName, "gate", "default", "name of the gate, such as 'canary-gate'")
+	annotateCmd.Flags().StringVar(&gateResultName, "gate-result", "", "decision of the gate: passed | failed")
+	annotateCmd.MarkFlagRequired("gate-result")
+	annotateCmd.Flags().StringVar(&pipelineURLEnv, "pipeline-url-env", "ITER8CTL_PIPELINE_URL", "environment variable containing the URL of the pipeline run, such as CI_PIPELINE_URL in GitLab or BUILD_URL in Jenkins")
+}
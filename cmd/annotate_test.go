@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAnnotate(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml")
+	os.Setenv("CI_PIPELINE_URL", "https://ci.example.com/runs/42")
+	defer os.Unsetenv("CI_PIPELINE_URL")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer func() {
+		gateName, gateResultName, pipelineURLEnv = "default", "", "ITER8CTL_PIPELINE_URL"
+	}()
+
+	rootCmd.SetArgs([]string{"annotate", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--gate", "canary-gate", "--gate-result", "passed", "--pipeline-url-env", "CI_PIPELINE_URL"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "Recorded gate canary-gate passed for experiment kfserving-test/sklearn-iris-experiment-1\n", out.String())
+
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(context.Background(), client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}, e))
+	decisions := (&expr.Experiment{Experiment: *e}).GetGateDecisions()
+	assert.Len(t, decisions, 1)
+	assert.Equal(t, "canary-gate", decisions[0].Gate)
+	assert.Equal(t, expr.GatePassed, decisions[0].Result)
+	assert.Equal(t, "https://ci.example.com/runs/42", decisions[0].PipelineRun)
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"describe", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--sections", "gates"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "****** Gate History ******")
+	assert.Contains(t, out.String(), "https://ci.example.com/runs/42")
+	sectionNames = nil
+
+	rootCmd.SetArgs([]string{"annotate", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--gate-result", "ok"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "invalid gate result: ok; expected one of passed, failed")
+	assert.Equal(t, ExitUsage, exitCode(err))
+
+	rootCmd.SetArgs([]string{"annotate", "missing", "-n", "kfserving-test", "--gate-result", "failed"})
+	assert.Equal(t, ExitNotFound, exitCode(rootCmd.Execute()))
+}
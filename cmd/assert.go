@@ -1,68 +1,181 @@
 package cmd
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"os"
+	"time"
 
 	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/junit"
+	"github.com/iter8-tools/iter8ctl/policy"
 	"github.com/spf13/cobra"
 )
 
 var conditions []string
-var conds []expr.ConditionType
+var assertOutput string
+var metrics []string
+var expressions []string
+var policyFile string
+var winner string
+var objectivesSatisfied []string
+var objectives []string
+var minIterations int32
+var minSampleSize int64
+var recommended string
+var emitEvent bool
+
+// assertions are the parsed conditions, metric assertions, objectives, expressions, and policy supplied to the assert command.
+type assertions struct {
+	conds               []expr.ConditionType
+	metricAssertions    []*expr.MetricAssertion
+	objectiveRefs       []expr.ObjectiveRef
+	compiledExpressions []*expr.Expression
+	gatePolicy          *policy.Policy
+}
+
+// parseAssertions parses the flags of the assert command.
+func parseAssertions() (*assertions, error) {
+	a := &assertions{}
+	for _, cond := range conditions {
+		c, err := expr.ParseConditionType(cond)
+		if err != nil {
+			return nil, usageError(err)
+		}
+		a.conds = append(a.conds, c)
+	}
+	// parse metric assertions
+	for _, m := range metrics {
+		ma, err := expr.ParseMetricAssertion(m)
+		if err != nil {
+			return nil, usageError(err)
+		}
+		a.metricAssertions = append(a.metricAssertions, ma)
+	}
+	// parse objectives
+	for _, o := range objectives {
+		ref, err := expr.ParseObjectiveRef(o)
+		if err != nil {
+			return nil, usageError(err)
+		}
+		a.objectiveRefs = append(a.objectiveRefs, ref)
+	}
+	// compile expressions
+	for _, x := range expressions {
+		cx, err := expr.CompileExpression(x)
+		if err != nil {
+			return nil, usageError(err)
+		}
+		a.compiledExpressions = append(a.compiledExpressions, cx)
+	}
+	// read policy
+	if policyFile != "" {
+		var err error
+		if a.gatePolicy, err = policy.FromFile(policyFile); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
 
 // assertCmd represents the assert command
 var assertCmd = &cobra.Command{
 	Use:   "assert [experiment-name]",
 	Short: "Assert conditions for an Iter8 experiment",
-	Long:  `One or more conditions can be asserted using this command for an Iter8 experiment. This command is especially useful in CI/CD/Gitops pipelines prior to version promotion or rollback. When experiment-name is omitted, the experiment with the latest creation timestamp in the cluster is used for assertions.`,
+	Long:  `One or more conditions, metric assertions, expressions, and policy rules can be asserted using this command for an Iter8 experiment. This command is especially useful in CI/CD/Gitops pipelines prior to version promotion or rollback. When experiment-name is omitted, the experiment with the latest creation timestamp in the cluster is used for assertions.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
-			return errors.New("More than one positional argument supplied")
+			return expr.NewError(expr.KindUsage, "More than one positional argument supplied")
 		}
 		latest = (len(args) == 0)
 		if !latest {
 			expName = args[0]
 		}
-		// at this stage, either latest must be true or expName must be non-empty
 		if !latest && expName == "" {
-			panic("either latest must be true or expName must be non-empty")
+			return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+		}
+		// parse conditions
+		if len(conditions) == 0 && len(metrics) == 0 && len(expressions) == 0 && policyFile == "" && winner == "" && recommended == "" &&
+			len(objectivesSatisfied) == 0 && len(objectives) == 0 && minIterations == 0 && minSampleSize == 0 {
+			return expr.NewError(expr.KindUsage, "One or more conditions, metric assertions, expressions, objectives, winner or recommended versions, minimum evidence, or a policy must be specified with assert")
+		}
+		// parse output format
+		if assertOutput != "" && assertOutput != "junit" {
+			return expr.NewError(expr.KindUsage, "Invalid output format: "+assertOutput)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := parseAssertions()
+		if err != nil {
+			return err
 		}
 		// get experiment from cluster
-		var err error
 		if exp, err = expr.GetExperiment(latest, expName, expNamespace); err != nil {
 			return err
 		}
-		// parse conditions
-		if conditions == nil || len(conditions) == 0 {
-			return errors.New("One or more conditions must be specified with assert")
-		}
-		for _, cond := range conditions {
-			switch cond {
-			case string(expr.Completed):
-				conds = append(conds, expr.Completed)
-			case string(expr.WinnerFound):
-				conds = append(conds, expr.WinnerFound)
-			default:
-				return errors.New("Invalid condition: " + cond)
-			}
+		res := exp.Assert(a.conds)
+		if winner != "" {
+			res.Results = append(res.Results, exp.AssertWinner(winner))
 		}
-		return nil
-	},
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := exp.Assert(conds); err == nil {
-			fmt.Println("All conditions satisfied.")
+		if recommended != "" {
+			res.Results = append(res.Results, exp.AssertRecommended(recommended))
+		}
+		for _, v := range objectivesSatisfied {
+			res.Results = append(res.Results, exp.AssertObjectivesSatisfied(v))
+		}
+		for _, ref := range a.objectiveRefs {
+			res.Results = append(res.Results, exp.AssertObjectiveRef(ref))
+		}
+		if minIterations > 0 {
+			res.Results = append(res.Results, exp.AssertMinIterations(minIterations))
+		}
+		if minSampleSize > 0 {
+			res.Results = append(res.Results, exp.AssertMinSampleSize(minSampleSize))
+		}
+		for _, ma := range a.metricAssertions {
+			res.Results = append(res.Results, exp.AssertMetric(ma))
+		}
+		for _, cx := range a.compiledExpressions {
+			res.Results = append(res.Results, exp.AssertExpression(cx))
+		}
+		if a.gatePolicy != nil {
+			res.Results = append(res.Results, a.gatePolicy.Evaluate(exp).Results...)
+		}
+		if assertOutput == "junit" {
+			if err := junit.Build(exp, res).Write(cmd.OutOrStdout()); err != nil {
+				return err
+			}
 		} else {
-			fmt.Println(err)
-			os.Exit(1)
+			fmt.Fprint(cmd.OutOrStdout(), res.Checklist())
+		}
+		// failing to record the event does not change the outcome of the assertion
+		if emitEvent {
+			if err := expr.EmitEvent(context.Background(), exp.NewAssertEvent(res, time.Now())); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not emit event for experiment %s/%s: %v\n", exp.Namespace, exp.Name, err)
+			}
+		}
+		err = res.Err()
+		if err != nil && exp.Failed() {
+			return &expr.Error{Kind: expr.KindExperimentFailed, Err: err}
 		}
+		return err
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(assertCmd)
 	assertCmd.Flags().StringSliceVarP(&conditions, "condition", "c", nil, "completed | winnerFound")
+	assertCmd.Flags().StringVar(&winner, "winner", "", "version that must be the winner of the experiment; baseline or candidate may be used to specify the version by its role")
+	assertCmd.Flags().StringVar(&recommended, "recommended", "", "version that must be recommended for promotion; baseline or candidate may be used to specify the version by its role")
+	assertCmd.Flags().StringSliceVar(&objectivesSatisfied, "objective-satisfied", nil, "version that must satisfy every objective of the experiment; baseline or candidate may be used to specify versions by their role; can be repeated")
+	assertCmd.Flags().StringSliceVar(&objectives, "objective", nil, "objective that must be satisfied, specified as <metric>@<version>, such as 'iter8-istio/mean-latency@B'; can be repeated")
+	assertCmd.Flags().Int32Var(&minIterations, "min-iterations", 0, "minimum number of completed iterations")
+	assertCmd.Flags().Int64Var(&minSampleSize, "min-sample-size", 0, "minimum number of requests per version according to spec.criteria.requestCount, and minimum sample size of every metric value with a known sample size")
+	assertCmd.Flags().StringArrayVarP(&metrics, "metric", "m", nil, "metric assertion such as 'iter8-istio/error-rate[B] < 0.005' or 'iter8-istio/mean-latency[B] <= 1.1 * iter8-istio/mean-latency[A]'; can be repeated")
+	assertCmd.Flags().StringArrayVarP(&expressions, "expr", "e", nil, "CEL expression over the experiment that must evaluate to true, such as 'winner == \"canary\" && metrics[\"iter8-istio/error-rate\"][\"canary\"] < 0.01'; see the Expression type in the experiment package for available variables; can be repeated")
+	assertCmd.Flags().StringVarP(&policyFile, "policy", "p", "", "YAML file declaring named rules to be asserted; see the policy package for its format")
+	assertCmd.Flags().StringVarP(&assertOutput, "output", "o", "", "output format; junit emits a JUnit XML report with one test case per assertion and per objective/version pair")
+	assertCmd.Flags().BoolVar(&emitEvent, "emit-event", false, "record the outcome of the assertion and the winner of the experiment as a K8s event on the experiment, visible with 'kubectl get events'; the event is a warning if the assertion is not satisfied, and failing to emit it does not change the exit code")
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAssertEmitEvent(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml")
+	defer func() {
+		conditions, winner, emitEvent = nil, "", false
+	}()
+
+	rootCmd.SetArgs([]string{"assert", "sklearn-iris-experiment-1", "-n", "kfserving-test", "-c", "completed,winnerFound", "--emit-event"})
+	assert.NoError(t, rootCmd.Execute())
+	events := &corev1.EventList{}
+	assert.NoError(t, rc.List(context.Background(), events, client.InNamespace("kfserving-test")))
+	assert.Len(t, events.Items, 1)
+	ev := events.Items[0]
+	assert.Equal(t, "Experiment", ev.InvolvedObject.Kind)
+	assert.Equal(t, "sklearn-iris-experiment-1", ev.InvolvedObject.Name)
+	assert.Equal(t, corev1.EventTypeNormal, ev.Type)
+	assert.Equal(t, "AssertionSatisfied", ev.Reason)
+	assert.Equal(t, "all 2 conditions satisfied; winner is canary", ev.Message)
+
+	// conditions are not asserted twice when the command runs again
+	rootCmd.SetArgs([]string{"assert", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--emit-event"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.NoError(t, rc.List(context.Background(), events, client.InNamespace("kfserving-test")))
+	assert.Len(t, events.Items, 2)
+	for _, ev := range events.Items {
+		assert.Equal(t, "all 2 conditions satisfied; winner is canary", ev.Message)
+	}
+
+	rootCmd.SetArgs([]string{"assert", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--winner", "default", "--emit-event"})
+	assert.Equal(t, ExitAssertionFailed, exitCode(rootCmd.Execute()))
+	assert.NoError(t, rc.List(context.Background(), events, client.InNamespace("kfserving-test")))
+	assert.Len(t, events.Items, 3)
+}
+
+// failingCreateClient fails to create any object.
+type failingCreateClient struct {
+	client.WithWatch
+}
+
+func (c *failingCreateClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return errors.New("create rejected")
+}
+
+func TestAssertEmitEventFails(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml")
+	testutil.InstallClient(t, &expr.GetClient, nil, &failingCreateClient{WithWatch: rc})
+	errOut := &bytes.Buffer{}
+	rootCmd.SetErr(errOut)
+	defer rootCmd.SetErr(nil)
+	defer func() {
+		conditions, winner, emitEvent = nil, "", false
+	}()
+
+	rootCmd.SetArgs([]string{"assert", "sklearn-iris-experiment-1", "-n", "kfserving-test", "-c", "completed", "--emit-event"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "Warning: could not emit event for experiment kfserving-test/sklearn-iris-experiment-1: create rejected\n", errOut.String())
+
+	errOut.Reset()
+	rootCmd.SetArgs([]string{"assert", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--winner", "default", "--emit-event"})
+	assert.Equal(t, ExitAssertionFailed, exitCode(rootCmd.Execute()))
+	assert.Contains(t, errOut.String(), "create rejected")
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// errNotConfirmed is returned when the user declines to proceed.
+var errNotConfirmed = errors.New("not confirmed; use --yes to proceed without confirmation")
+
+// confirm asks the user whether to proceed, and returns errNotConfirmed unless they answer yes on the input of cmd.
+func confirm(cmd *cobra.Command, question string) error {
+	fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errNotConfirmed
+	}
+}
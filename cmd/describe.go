@@ -1,29 +1,66 @@
 package cmd
 
 import (
-	"errors"
+	"os"
 
 	"github.com/iter8-tools/iter8ctl/describe"
 	expr "github.com/iter8-tools/iter8ctl/experiment"
 	"github.com/spf13/cobra"
 )
 
+var sectionNames []string
+var sections []describe.Section
+var compact bool
+var describeOutput string
+var describeHistory string
+var snapshots []expr.Snapshot
+
 // describeCmd represents the describe command
 var describeCmd = &cobra.Command{
 	Use:   "describe [experiment-name]",
 	Short: "Describe an Iter8 experiment",
 	Long:  `Summarize an experiment, including the stage of the experiment, how versions are performing with respect to the experiment criteria (reward, SLOs, metrics), and information about the winning version. When experiment-name is omitted, the experiment with the latest creation timestamp in the cluster is described.`,
+	Example: `  iter8ctl describe my-experiment -n my-namespace --sections winner,objectives
+  iter8ctl describe my-experiment -n my-namespace --sections metrics --history history.jsonl
+  iter8ctl describe my-experiment -n my-namespace -o go-template='{{.Winner}}'
+  iter8ctl describe my-experiment -n my-namespace -o jsonpath='{.metrics[?(@.name=="error-rate")].values.canary}'`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
-			return errors.New("more than one positional argument supplied")
+			return expr.NewError(expr.KindUsage, "more than one positional argument supplied")
 		}
 		latest = (len(args) == 0)
 		if !latest {
 			expName = args[0]
 		}
-		// at this stage, either latest must be true or expName must be non-empty
 		if !latest && expName == "" {
-			panic("either latest must be true or expName must be non-empty")
+			return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+		}
+		// parse sections
+		if compact && len(sectionNames) > 0 {
+			return expr.NewError(expr.KindUsage, "--sections and --compact cannot be used together")
+		}
+		if describeOutput != string(describe.FormatText) && (compact || len(sectionNames) > 0 || describeHistory != "") {
+			return expr.NewError(expr.KindUsage, "--sections, --compact, and --history can only be used with text output")
+		}
+		sections = nil
+		for _, name := range sectionNames {
+			s, err := describe.ParseSection(name)
+			if err != nil {
+				return usageError(err)
+			}
+			sections = append(sections, s)
+		}
+		// read history
+		snapshots = nil
+		if describeHistory != "" {
+			f, err := os.Open(describeHistory)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if snapshots, err = expr.ReadSnapshots(f); err != nil {
+				return err
+			}
 		}
 		// get experiment from cluster
 		var err error
@@ -32,13 +69,28 @@ var describeCmd = &cobra.Command{
 		}
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		describe.Builder().WithExperiment(exp).PrintAnalysis()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := describe.Format(describeOutput)
+		if compact {
+			format = describe.FormatCompact
+		}
+		out := cmd.OutOrStdout()
+		return describe.Builder().
+			WithExperiment(exp).
+			WithSections(sections...).
+			WithHistory(snapshots).
+			WithWidth(terminalWidth(out)).
+			WithColor(colorEnabled(out)).
+			Render(out, format).Error()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(describeCmd)
+	describeCmd.Flags().StringSliceVar(&sectionNames, "sections", nil, "comma-separated sections to describe; one or more of overview, progress, winner, rewards, objectives, metrics, gates (default all)")
+	describeCmd.Flags().BoolVar(&compact, "compact", false, "describe the experiment with a one line summary per version")
+	describeCmd.Flags().StringVar(&describeHistory, "history", "", "file containing snapshots recorded by 'iter8ctl record'; adds the trend of each metric to the metrics section")
+	describeCmd.Flags().StringVarP(&describeOutput, "output", "o", string(describe.FormatText), "output format; one of text, json, yaml, go-template=<template>, jsonpath=<expression>")
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
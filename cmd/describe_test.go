@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{"describe", "-n", "kfserving-test", "sklearn-iris-experiment-1"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Experiment name: sklearn-iris-experiment-1")
+	assert.Contains(t, out.String(), "Winning version: canary")
+
+	rootCmd.SetArgs([]string{"describe", "-n", "kfserving-test", "missing"})
+	err := rootCmd.Execute()
+	assert.Equal(t, ExitNotFound, exitCode(err))
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"describe", "--sections", "winner"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Winning version: canary")
+	assert.NotContains(t, out.String(), "Experiment name")
+
+	rootCmd.SetArgs([]string{"describe", "--sections", "winners"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+
+	out.Reset()
+	sectionNames = nil
+	rootCmd.SetArgs([]string{"describe", "--compact"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "kfserving-test/sklearn-iris-experiment-1: stage unavailable, 10 iterations, winner canary\ndefault: objectives 2/2\ncanary (winner, recommended): objectives 2/2\n", out.String())
+	compact = false
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"describe", "-o", "jsonpath={.winner}"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "canary", out.String())
+
+	rootCmd.SetArgs([]string{"describe", "-o", "jsonpath={.winner}", "--compact"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+	compact = false
+	describeOutput = "text"
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"describe", "--sections", "metrics", "--history", "../testdata/history.jsonl"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "| mean-latency (milliseconds)    | 228.420 █▅▂▁ ↓ | 229.002 █▅▂▁ ↓ |")
+	sectionNames = nil
+
+	rootCmd.SetArgs([]string{"describe", "-o", "json", "--history", "../testdata/history.jsonl"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+	describeOutput = "text"
+	describeHistory = ""
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/server"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// exportMetricsCmd represents the export-metrics command
+var exportMetricsCmd = &cobra.Command{
+	Use:   "export-metrics",
+	Short: "Export the state of Iter8 experiments as Prometheus metrics",
+	Long: `Write gauges describing the experiments in the cluster in the Prometheus text format; for example, for use with the textfile collector of the node exporter. When --namespace is specified, only experiments in that namespace are exported. To expose these gauges for scraping instead, use the /metrics endpoint of 'iter8ctl serve'. Every gauge is labeled with the namespace and name of its experiment.
+
+  iter8_experiment_completed_iterations
+      number of completed iterations
+  iter8_experiment_metric_value{version,metric}
+      most recently observed value of the metric for the version
+  iter8_experiment_objective_satisfied{version,objective,objective_index}
+      1 if the version satisfies the objective, and 0 if it does not
+  iter8_experiment_winner{version}
+      1 if the version is the winner, and 0 otherwise
+  iter8_experiment_weight{version}
+      traffic weight currently applied to the version`,
+	Example: `  iter8ctl export-metrics > /var/lib/node_exporter/textfile_collector/iter8.prom`,
+	Args:    usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace := ""
+		if cmd.Flags().Changed("namespace") {
+			namespace = expNamespace
+		}
+		exps, err := expr.ListExperiments(context.Background(), namespace)
+		if err != nil {
+			return err
+		}
+		cache := server.NewCache()
+		for _, exp := range exps {
+			cache.Apply(expr.Event{Type: watch.Added, Experiment: exp})
+		}
+		return server.WriteMetrics(cmd.OutOrStdout(), cache)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportMetricsCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+)
+
+var historyFile string
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:     "history",
+	Short:   "Render the recorded history of Iter8 experiments",
+	Long:    `Render snapshots recorded by 'iter8ctl record', including when the winning version changed, and how the value of each metric evolved for each version.`,
+	Example: `  iter8ctl history -f history.jsonl`,
+	Args:    usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(historyFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		snapshots, err := expr.ReadSnapshots(f)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		return describe.Builder().
+			WithHistory(snapshots).
+			WithWidth(terminalWidth(out)).
+			WithColor(colorEnabled(out)).
+			RenderHistory(out).Error()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVarP(&historyFile, "file", "f", "", "file containing snapshots recorded by 'iter8ctl record'")
+	historyCmd.MarkFlagRequired("file")
+}
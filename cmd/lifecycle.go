@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+)
+
+var lifecycleDryRun bool
+var lifecycleYes bool
+var lifecycleTimeout time.Duration
+
+// lifecycleAction is a change to the progress of an experiment made by the terminate, pause, and resume commands.
+type lifecycleAction struct {
+	// verb names the action, such as "terminate"; it is the name of its command
+	verb string
+	// past is the past tense of verb
+	past    string
+	short   string
+	long    string
+	example string
+	// patch returns the merge patch which makes the change to the experiment
+	patch func(*expr.Experiment) ([]byte, error)
+	// done indicates if the status reported by the controller reflects the change, given the experiment before the change; it is nil if the controller reports no such status
+	done func(before *expr.Experiment, current *expr.Experiment) bool
+	// describe describes the state of the experiment after the change
+	describe func(*expr.Experiment) string
+}
+
+// stageStr returns the stage of the experiment, or "unavailable".
+func stageStr(e *expr.Experiment) string {
+	if e.Status.Stage == nil {
+		return "unavailable"
+	}
+	return string(*e.Status.Stage)
+}
+
+var lifecycleActions = []lifecycleAction{{
+	verb:  "terminate",
+	past:  "terminated",
+	short: "Terminate an Iter8 experiment",
+	long: `Terminate an experiment after its current iteration. The experiment is finished in the usual manner; for example, its finish handler promotes the version recommended for promotion. An experiment which has not completed any iteration runs one iteration before it is finished.
+
+Termination is a best-effort workaround: the iter8 controller recognizes no request to terminate an experiment. Instead, spec.duration of the experiment is patched so that its completed iterations are all the iterations it requires, and the controller finishes it as though it had run its course.
+
+The current state of the experiment is shown, and confirmation is requested before the experiment is patched. Once patched, this command waits until the controller reports the experiment as finishing or completed.`,
+	example: `  iter8ctl terminate my-experiment -n my-namespace
+  iter8ctl terminate my-experiment -n my-namespace --dry-run`,
+	patch: (*expr.Experiment).TerminatePatch,
+	done: func(before *expr.Experiment, current *expr.Experiment) bool {
+		return current.Completed() || (current.Status.Stage != nil && current.Status.Stage.After(v2alpha2.ExperimentStageRunning))
+	},
+	describe: func(e *expr.Experiment) string {
+		return "is in stage " + stageStr(e)
+	},
+}, {
+	verb:  "pause",
+	past:  "paused",
+	short: "Pause an Iter8 experiment",
+	long: `Pause an experiment, so that no further iterations are started until it is resumed using 'iter8ctl resume'. Experiments cannot be paused before their first iteration completes.
+
+Pausing is a best-effort workaround: the iter8 controller recognizes no request to pause an experiment, and reports no paused state. Instead, the interval between iterations in spec.duration of the experiment is increased to the largest possible value, and its current value is recorded in the ` + expr.PausedIntervalAnnotation + ` annotation. An iteration in progress may still complete.
+
+The current state of the experiment is shown, and confirmation is requested before the experiment is patched. Since the controller reports no paused state, this command does not wait for the pause to take effect.`,
+	example: `  iter8ctl pause my-experiment -n my-namespace
+  iter8ctl resume my-experiment -n my-namespace`,
+	patch: (*expr.Experiment).PausePatch,
+	describe: func(e *expr.Experiment) string {
+		return "will not start further iterations until resumed"
+	},
+}, {
+	verb:  "resume",
+	past:  "resumed",
+	short: "Resume a paused Iter8 experiment",
+	long: `Resume an experiment paused using 'iter8ctl pause', by restoring the interval between iterations recorded in the ` + expr.PausedIntervalAnnotation + ` annotation. The next iteration starts once this interval has passed since the previous iteration. Like pausing, resuming is a best-effort workaround, since the iter8 controller recognizes no request to resume an experiment.
+
+The current state of the experiment is shown, and confirmation is requested before the experiment is patched. Once patched, this command waits until the controller reports that the experiment has completed another iteration.`,
+	example: `  iter8ctl resume my-experiment -n my-namespace --yes`,
+	patch:   (*expr.Experiment).ResumePatch,
+	done: func(before *expr.Experiment, current *expr.Experiment) bool {
+		return current.Completed() || current.Status.GetCompletedIterations() > before.Status.GetCompletedIterations()
+	},
+	describe: func(e *expr.Experiment) string {
+		return fmt.Sprintf("has completed %d iterations", e.Status.GetCompletedIterations())
+	},
+}}
+
+// newLifecycleCmd returns the command performing the action.
+func newLifecycleCmd(a lifecycleAction) *cobra.Command {
+	c := &cobra.Command{
+		Use:     a.verb + " experiment-name",
+		Short:   a.short,
+		Long:    a.long,
+		Example: a.example,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return expr.NewError(expr.KindUsage, "exactly one experiment name must be supplied")
+			}
+			if expName = args[0]; expName == "" {
+				return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+			}
+			// get experiment from cluster
+			var err error
+			if exp, err = expr.GetExperiment(false, expName, expNamespace); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLifecycleAction(cmd, a)
+		},
+	}
+	c.Flags().BoolVar(&lifecycleDryRun, "dry-run", false, "show the patch of the experiment without applying it")
+	c.Flags().BoolVarP(&lifecycleYes, "yes", "y", false, "proceed without confirmation")
+	if a.done != nil {
+		c.Flags().DurationVar(&lifecycleTimeout, "timeout", 5*time.Minute, "time to wait for the controller to report the change; 0 returns without waiting")
+	}
+	return c
+}
+
+// runLifecycleAction patches exp to perform the action, after showing its current state and asking for confirmation, and waits for the controller to report the change.
+func runLifecycleAction(cmd *cobra.Command, a lifecycleAction) error {
+	out := cmd.OutOrStdout()
+	patch, err := a.patch(exp)
+	if err != nil {
+		return err
+	}
+	if err := describe.Builder().WithExperiment(exp).Render(out, describe.FormatCompact).Error(); err != nil {
+		return err
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, patch, "", "  "); err != nil {
+		return err
+	}
+	if lifecycleDryRun {
+		fmt.Fprintf(out, "Merge patch of experiment %s/%s (dry run; not applied):\n%s\n", exp.Namespace, exp.Name, indented)
+		return nil
+	}
+	if !lifecycleYes {
+		if err := confirm(cmd, fmt.Sprintf("Proceed to %s experiment %s/%s?", a.verb, exp.Namespace, exp.Name)); err != nil {
+			return err
+		}
+	}
+	if err := expr.PatchExperiment(context.Background(), exp.Namespace, exp.Name, patch); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Experiment %s/%s %s\n", exp.Namespace, exp.Name, a.past)
+	if a.done == nil || lifecycleTimeout <= 0 {
+		if a.done == nil {
+			fmt.Fprintf(out, "Experiment %s/%s %s\n", exp.Namespace, exp.Name, a.describe(exp))
+		}
+		return nil
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+	ctx, cancelTimeout := context.WithTimeout(ctx, lifecycleTimeout)
+	defer cancelTimeout()
+	before := exp
+	current, err := expr.WaitForExperiment(ctx, exp.Namespace, exp.Name, func(e *expr.Experiment) bool {
+		return a.done(before, e)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Experiment %s/%s %s\n", current.Namespace, current.Name, a.describe(current))
+	return nil
+}
+
+func init() {
+	for _, a := range lifecycleActions {
+		rootCmd.AddCommand(newLifecycleCmd(a))
+	}
+}
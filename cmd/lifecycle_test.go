@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resetLifecycleFlags restores the flags of the terminate, pause, and resume commands to their defaults.
+func resetLifecycleFlags() {
+	lifecycleDryRun, lifecycleYes, lifecycleTimeout = false, false, 5*time.Minute
+}
+
+func TestTerminateDryRun(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer resetLifecycleFlags()
+
+	rootCmd.SetArgs([]string{"terminate", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--dry-run"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), `Merge patch of experiment kfserving-test/sklearn-iris-experiment-1 (dry run; not applied):
+{
+  "spec": {
+    "duration": {
+      "iterationsPerLoop": 7,
+      "maxLoops": 1
+    }
+  }
+}
+`)
+	assert.NotContains(t, out.String(), "terminated")
+}
+
+func TestTerminate(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetIn(nil)
+	defer resetLifecycleFlags()
+	key := client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}
+
+	rootCmd.SetIn(strings.NewReader("n\n"))
+	rootCmd.SetArgs([]string{"terminate", "sklearn-iris-experiment-1", "-n", "kfserving-test"})
+	err := rootCmd.Execute()
+	assert.Equal(t, errNotConfirmed, err)
+	assert.Contains(t, out.String(), "Proceed to terminate experiment kfserving-test/sklearn-iris-experiment-1? [y/N]: ")
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(context.Background(), key, e))
+	assert.Equal(t, int32(10), e.Spec.GetIterationsPerLoop())
+
+	// act as the controller, which finishes the experiment once it is patched
+	go func() {
+		for {
+			time.Sleep(20 * time.Millisecond)
+			e := &v2alpha2.Experiment{}
+			if err := rc.Get(context.Background(), key, e); err != nil || e.Spec.GetIterationsPerLoop() != 7 {
+				continue
+			}
+			stage := v2alpha2.ExperimentStageFinishing
+			e.Status.Stage = &stage
+			rc.Status().Update(context.Background(), e)
+			return
+		}
+	}()
+	out.Reset()
+	rootCmd.SetIn(strings.NewReader("y\n"))
+	rootCmd.SetArgs([]string{"terminate", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--timeout", "10s"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Experiment kfserving-test/sklearn-iris-experiment-1 terminated\nExperiment kfserving-test/sklearn-iris-experiment-1 is in stage Finishing\n")
+	assert.NoError(t, rc.Get(context.Background(), key, e))
+	assert.Equal(t, int32(1), e.Spec.GetMaxLoops())
+}
+
+func TestPauseAndResume(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer resetLifecycleFlags()
+	key := client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}
+
+	rootCmd.SetArgs([]string{"pause", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--yes"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Experiment kfserving-test/sklearn-iris-experiment-1 paused\nExperiment kfserving-test/sklearn-iris-experiment-1 will not start further iterations until resumed\n")
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(context.Background(), key, e))
+	assert.Equal(t, "15", e.Annotations[expr.PausedIntervalAnnotation])
+
+	rootCmd.SetArgs([]string{"pause", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--yes"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 is already paused")
+	assert.Equal(t, ExitUsage, exitCode(err))
+
+	// act as the controller, which completes another iteration once the interval is restored
+	go func() {
+		for {
+			time.Sleep(20 * time.Millisecond)
+			e := &v2alpha2.Experiment{}
+			if err := rc.Get(context.Background(), key, e); err != nil || e.Spec.GetIntervalSeconds() != 15 {
+				continue
+			}
+			iterations := e.Status.GetCompletedIterations() + 1
+			e.Status.CompletedIterations = &iterations
+			rc.Status().Update(context.Background(), e)
+			return
+		}
+	}()
+	out.Reset()
+	rootCmd.SetArgs([]string{"resume", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--yes", "--timeout", "10s"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Experiment kfserving-test/sklearn-iris-experiment-1 resumed\nExperiment kfserving-test/sklearn-iris-experiment-1 has completed 8 iterations\n")
+	e = &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(context.Background(), key, e))
+	assert.NotContains(t, e.Annotations, expr.PausedIntervalAnnotation)
+	assert.Equal(t, int32(15), e.Spec.GetIntervalSeconds())
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/notify"
+	"github.com/spf13/cobra"
+)
+
+var webhookURL string
+var notifyOn []string
+var notifyFormat string
+var notifyRetries int
+var webhook *notify.Webhook
+var triggers []notify.Trigger
+
+// webhookSecretEnv is the environment variable containing the secret with which notifications are signed.
+const webhookSecretEnv = "ITER8CTL_WEBHOOK_SECRET"
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Notify a webhook when Iter8 experiments complete, find a winner, or fail",
+	Long: `Watch experiments in the cluster, and POST a notification to a webhook when an experiment completes, finds a winner, or fails. When --namespace is specified, only experiments in that namespace are watched. Transitions which happened before this command started are not notified. Notifications which are not accepted because of a network error, or a 429 or 5xx response, are retried with exponential backoff. This command runs until interrupted.
+
+In json format, a notification contains the event, a message, the time, the compact description of the experiment as with 'iter8ctl describe -o compact', and the structured description of the experiment as with 'iter8ctl describe -o json'. In slack format, a notification is a message suitable for Slack incoming webhooks.
+
+The ` + notify.EventHeader + ` header of a notification contains its event. When the ` + webhookSecretEnv + ` environment variable is set, notifications are signed with it using HMAC-SHA256, and the ` + notify.SignatureHeader + ` header contains the signature in the form sha256=<hex digest>.`,
+	Example: `  iter8ctl notify --webhook https://example.com/hooks/iter8 --on completed,failure
+  iter8ctl notify -n my-namespace --webhook https://hooks.slack.com/services/... --format slack`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.NoArgs(cmd, args); err != nil {
+			return usageError(err)
+		}
+		if notifyRetries < 0 {
+			return expr.NewError(expr.KindUsage, fmt.Sprintf("invalid number of retries: %v; must be non-negative", notifyRetries))
+		}
+		triggers = nil
+		for _, s := range notifyOn {
+			t, err := notify.ParseTrigger(s)
+			if err != nil {
+				return usageError(err)
+			}
+			triggers = append(triggers, t)
+		}
+		format, err := notify.ParseFormat(notifyFormat)
+		if err != nil {
+			return usageError(err)
+		}
+		webhook = notify.NewWebhook(webhookURL)
+		webhook.Format = format
+		webhook.Retries = notifyRetries
+		webhook.Secret = []byte(os.Getenv(webhookSecretEnv))
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace := ""
+		if cmd.Flags().Changed("namespace") {
+			namespace = expNamespace
+		}
+		ctx, cancel := interruptContext()
+		defer cancel()
+		return notify.NewNotifier(webhook, triggers).Run(ctx, namespace, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().StringVar(&webhookURL, "webhook", "", "URL to which notifications are POSTed")
+	notifyCmd.MarkFlagRequired("webhook")
+	notifyCmd.Flags().StringSliceVar(&notifyOn, "on", []string{string(notify.TriggerCompleted), string(notify.TriggerWinnerFound), string(notify.TriggerFailure)}, "events for which notifications are sent: completed | winnerFound | failure")
+	notifyCmd.Flags().StringVar(&notifyFormat, "format", string(notify.FormatJSON), "format of notifications: json | slack")
+	notifyCmd.Flags().IntVar(&notifyRetries, "retries", 3, "number of times a notification is retried")
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/iter8-tools/iter8ctl/notify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyInvalidFlags(t *testing.T) {
+	defer func() {
+		notifyOn = []string{string(notify.TriggerCompleted), string(notify.TriggerWinnerFound), string(notify.TriggerFailure)}
+		notifyFormat = string(notify.FormatJSON)
+		notifyRetries = 3
+	}()
+
+	rootCmd.SetArgs([]string{"notify", "--webhook", "http://localhost", "--on", "completed,done"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "Invalid trigger: done")
+	assert.Equal(t, ExitUsage, exitCode(err))
+
+	notifyOn = nil
+	rootCmd.SetArgs([]string{"notify", "--webhook", "http://localhost", "--on", "completed", "--format", "xml"})
+	assert.EqualError(t, rootCmd.Execute(), "invalid notification format: xml; expected one of json, slack")
+
+	notifyOn = nil
+	rootCmd.SetArgs([]string{"notify", "--webhook", "http://localhost", "--on", "completed", "--retries", "-1"})
+	err = rootCmd.Execute()
+	assert.EqualError(t, err, "invalid number of retries: -1; must be non-negative")
+	assert.Equal(t, ExitUsage, exitCode(err))
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+)
+
+var promoteVersion string
+var promoteDryRun bool
+var promoteYes bool
+
+// promoteArgs validates the arguments of the promote and rollback commands, and gets the experiment from the cluster.
+func promoteArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return expr.NewError(expr.KindUsage, "exactly one experiment name must be supplied")
+	}
+	if expName = args[0]; expName == "" {
+		return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+	}
+	// get experiment from cluster
+	var err error
+	if exp, err = expr.GetExperiment(false, expName, expNamespace); err != nil {
+		return err
+	}
+	return nil
+}
+
+// promoteCmd represents the promote command
+var promoteCmd = &cobra.Command{
+	Use:   "promote experiment-name",
+	Short: "Send all traffic of a completed Iter8 experiment to a version",
+	Long: `Promote a version of a completed experiment by sending all its traffic to the version, for example when the experiment ended without a winner. The version recommended for promotion is maintained by the iter8 controller and cannot be overridden; instead, the field referred to by the weightObjRef of every version is patched, setting the weight of the promoted version to 100 and the weights of the other versions to 0, as the controller does when it redistributes traffic. Versions without a weightObjRef are not patched.
+
+The current state of the experiment and the JSON patches are shown, and confirmation is requested before the patches are applied. Each patch tests the current weights before replacing them; every patched object is read first, so that no object is patched unless all of them can be.`,
+	Example: `  iter8ctl promote my-experiment -n my-namespace --version B
+  iter8ctl promote my-experiment -n my-namespace --version candidate --dry-run`,
+	Args: promoteArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPromote(cmd, promoteVersion, "promote", "Promoted")
+	},
+}
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback experiment-name",
+	Short: "Send all traffic of a completed Iter8 experiment to its baseline version",
+	Long:  `Roll back a completed experiment by sending all its traffic to its baseline version. This is equivalent to 'iter8ctl promote --version baseline'.`,
+	Example: `  iter8ctl rollback my-experiment -n my-namespace
+  iter8ctl rollback my-experiment -n my-namespace --dry-run`,
+	Args: promoteArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPromote(cmd, expr.BaselineRole, "roll back", "Rolled back")
+	},
+}
+
+// runPromote patches the weights of the versions of exp to send all traffic to the given version, after showing the patches and asking for confirmation.
+// verb names the action in the confirmation, such as "promote", and past is its past tense, such as "Promoted".
+func runPromote(cmd *cobra.Command, versionOrRole string, verb string, past string) error {
+	out := cmd.OutOrStdout()
+	patches, err := exp.PromotePatches(versionOrRole)
+	if err != nil {
+		return err
+	}
+	// every patch is guarded, and thereby validated, before any is applied
+	for i := range patches {
+		if patches[i], err = expr.GuardWeightPatch(context.Background(), patches[i]); err != nil {
+			return err
+		}
+	}
+	if err := describe.Builder().WithExperiment(exp).Render(out, describe.FormatCompact).Error(); err != nil {
+		return err
+	}
+	for _, p := range patches {
+		indented := &bytes.Buffer{}
+		if err := json.Indent(indented, p.Patch, "", "  "); err != nil {
+			return err
+		}
+		note := ""
+		if promoteDryRun {
+			note = " (dry run; not applied)"
+		}
+		fmt.Fprintf(out, "JSON patch of %s %s/%s%s:\n%s\n", p.Object.Kind, p.Object.Namespace, p.Object.Name, note, indented)
+	}
+	if promoteDryRun {
+		return nil
+	}
+	if !promoteYes {
+		if err := confirm(cmd, fmt.Sprintf("Proceed to %s experiment %s/%s to version %s?", verb, exp.Namespace, exp.Name, versionOrRole)); err != nil {
+			return err
+		}
+	}
+	var patched []string
+	for _, p := range patches {
+		if err := expr.ApplyWeightPatch(context.Background(), p); err != nil {
+			if len(patched) > 0 {
+				return &expr.Error{Kind: expr.KindOf(err), Err: fmt.Errorf("%v; already patched: %s", err, strings.Join(patched, ", "))}
+			}
+			return err
+		}
+		fmt.Fprintf(out, "Patched %s %s/%s\n", p.Object.Kind, p.Object.Namespace, p.Object.Name)
+		patched = append(patched, fmt.Sprintf("%s %s/%s", p.Object.Kind, p.Object.Namespace, p.Object.Name))
+	}
+	fmt.Fprintf(out, "%s experiment %s/%s to version %s\n", past, exp.Namespace, exp.Name, versionOrRole)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+	promoteCmd.Flags().StringVar(&promoteVersion, "version", "", "name of the promoted version, or its role: baseline | candidate")
+	promoteCmd.MarkFlagRequired("version")
+	rootCmd.AddCommand(rollbackCmd)
+	for _, c := range []*cobra.Command{promoteCmd, rollbackCmd} {
+		c.Flags().BoolVar(&promoteDryRun, "dry-run", false, "show the patches without applying them")
+		c.Flags().BoolVarP(&promoteYes, "yes", "y", false, "proceed without confirmation")
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// virtualService returns a virtual service named bookinfo-iter8/name, with a route of the given weight for each version.
+func virtualService(name string, weights ...int64) *unstructured.Unstructured {
+	vs := &unstructured.Unstructured{}
+	vs.SetAPIVersion("networking.istio.io/v1beta1")
+	vs.SetKind("VirtualService")
+	vs.SetNamespace("bookinfo-iter8")
+	vs.SetName(name)
+	var routes []interface{}
+	for _, w := range weights {
+		routes = append(routes, map[string]interface{}{"weight": w})
+	}
+	unstructured.SetNestedSlice(vs.Object, []interface{}{map[string]interface{}{"route": routes}}, "spec", "http")
+	return vs
+}
+
+func TestPromoteDryRun(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment12.yaml")
+	assert.NoError(t, rc.Create(context.Background(), virtualService("bookinfo", 10, 90)))
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer func() {
+		promoteVersion, promoteDryRun = "", false
+	}()
+
+	rootCmd.SetArgs([]string{"promote", "istio-quickstart", "-n", "default", "--version", "B", "--dry-run"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), `JSON patch of VirtualService bookinfo-iter8/bookinfo (dry run; not applied):
+[
+  {
+    "op": "test",
+    "path": "/spec/http/0/route/0/weight",
+    "value": 10
+  },
+  {
+    "op": "replace",
+    "path": "/spec/http/0/route/0/weight",
+    "value": 0
+  },
+  {
+    "op": "test",
+    "path": "/spec/http/0/route/1/weight",
+    "value": 90
+  },
+  {
+    "op": "replace",
+    "path": "/spec/http/0/route/1/weight",
+    "value": 100
+  }
+]
+`)
+	assert.NotContains(t, out.String(), "Promoted experiment")
+
+	rootCmd.SetArgs([]string{"promote", "istio-quickstart", "-n", "default", "--version", "C", "--dry-run"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "unknown version C; versions in experiment: [A, B]")
+	assert.Equal(t, ExitUsage, exitCode(err))
+}
+
+func TestRollback(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment12.yaml")
+	assert.NoError(t, rc.Create(context.Background(), virtualService("bookinfo", 10, 90)))
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetIn(nil)
+	defer func() {
+		promoteYes = false
+	}()
+
+	rootCmd.SetIn(strings.NewReader("\n"))
+	rootCmd.SetArgs([]string{"rollback", "istio-quickstart", "-n", "default"})
+	assert.Equal(t, errNotConfirmed, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Proceed to roll back experiment default/istio-quickstart to version baseline? [y/N]: ")
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"rollback", "istio-quickstart", "-n", "default", "--yes"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Patched VirtualService bookinfo-iter8/bookinfo\nRolled back experiment default/istio-quickstart to version baseline\n")
+
+	assert.EqualValues(t, 100, weight(t, rc, "bookinfo", 0))
+	assert.EqualValues(t, 0, weight(t, rc, "bookinfo", 1))
+
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	rootCmd.SetArgs([]string{"rollback", "sklearn-iris-experiment-1", "-n", "kfserving-test", "--yes"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+}
+
+// failingPatchClient fails to patch the object with the given name.
+type failingPatchClient struct {
+	client.WithWatch
+	name string
+}
+
+func (c *failingPatchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if obj.GetName() == c.name {
+		return errors.New("patch rejected")
+	}
+	return c.WithWatch.Patch(ctx, obj, patch, opts...)
+}
+
+func TestPromoteValidatesBeforePatching(t *testing.T) {
+	exp := testutil.ReadExperiment(t, "experiment12.yaml")
+	exp.Spec.VersionInfo.Candidates[0].WeightObjRef.Name = "bookinfo-canary"
+	rc := testutil.WithFakeObjects(t, &expr.GetClient, &expr.GetWatchClient, exp, virtualService("bookinfo", 10, 90))
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer func() {
+		promoteVersion, promoteYes = "", false
+	}()
+
+	// no object is patched unless all of them exist
+	rootCmd.SetArgs([]string{"promote", "istio-quickstart", "-n", "default", "--version", "B", "--yes"})
+	err := rootCmd.Execute()
+	assert.Equal(t, ExitNotFound, exitCode(err))
+	assert.NotContains(t, out.String(), "Patched")
+	assert.EqualValues(t, 10, weight(t, rc, "bookinfo", 0))
+
+	// objects patched before a failure are reported
+	assert.NoError(t, rc.Create(context.Background(), virtualService("bookinfo-canary", 10, 90)))
+	testutil.InstallClient(t, &expr.GetClient, nil, &failingPatchClient{WithWatch: rc, name: "bookinfo-canary"})
+	err = rootCmd.Execute()
+	assert.EqualError(t, err, "patch rejected; already patched: VirtualService bookinfo-iter8/bookinfo")
+	assert.EqualValues(t, 0, weight(t, rc, "bookinfo", 0))
+	assert.EqualValues(t, 90, weight(t, rc, "bookinfo-canary", 1))
+}
+
+// weight returns the weight of the route with the given index of the virtual service bookinfo-iter8/name.
+func weight(t *testing.T, rc client.Client, name string, route int) interface{} {
+	vs := virtualService(name)
+	assert.NoError(t, rc.Get(context.Background(), client.ObjectKey{Namespace: "bookinfo-iter8", Name: name}, vs))
+	http, _, _ := unstructured.NestedSlice(vs.Object, "spec", "http")
+	return http[0].(map[string]interface{})["route"].([]interface{})[route].(map[string]interface{})["weight"]
+}
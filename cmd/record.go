@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var recordOut string
+
+// recordCmd represents the record command
+var recordCmd = &cobra.Command{
+	Use:   "record experiment-name",
+	Short: "Record the history of an Iter8 experiment",
+	Long:  `Watch an experiment, and append a snapshot of its analysis, including aggregated metrics, version assessments, weights, and winner assessment, to a file whenever its status changes. Snapshots are written in JSON lines format. Recording stops when the experiment completes or is deleted, or when interrupted. Use 'iter8ctl history' to render the recorded history.`,
+	Example: `  iter8ctl record my-experiment -n my-namespace --out history.jsonl
+  iter8ctl history -f history.jsonl`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return expr.NewError(expr.KindUsage, "exactly one experiment name must be supplied")
+		}
+		if expName = args[0]; expName == "" {
+			return expr.NewError(expr.KindUsage, "experiment name must be non-empty")
+		}
+		// get experiment from cluster
+		var err error
+		if exp, err = expr.GetExperiment(false, expName, expNamespace); err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.OpenFile(recordOut, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+		events, err := expr.WatchExperiments(ctx, expNamespace)
+		if err != nil {
+			return err
+		}
+
+		var last *expr.Snapshot
+		record := func(e *expr.Experiment) error {
+			s := e.GetSnapshot(time.Now())
+			if last != nil && last.SameStatus(s) {
+				return nil
+			}
+			last = &s
+			if err := expr.WriteSnapshot(f, s); err != nil {
+				return err
+			}
+			iterations := int32(0)
+			if s.CompletedIterations != nil {
+				iterations = *s.CompletedIterations
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Recorded snapshot of %s/%s after %d iterations\n", s.Namespace, s.Name, iterations)
+			return nil
+		}
+
+		if err := record(exp); err != nil {
+			return err
+		}
+		for !exp.Completed() {
+			ev, ok := <-events
+			if !ok {
+				if ctx.Err() != nil {
+					// interrupted
+					return nil
+				}
+				return expr.NewError(expr.KindCluster, "watch of experiments ended")
+			}
+			if ev.Experiment.Name != expName || ev.Experiment.Namespace != expNamespace {
+				continue
+			}
+			if ev.Type == watch.Deleted {
+				fmt.Fprintf(cmd.OutOrStdout(), "Experiment %s/%s was deleted\n", expNamespace, expName)
+				return nil
+			}
+			exp = ev.Experiment
+			if err := record(exp); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Experiment %s/%s completed\n", expNamespace, expName)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+	recordCmd.Flags().StringVar(&recordOut, "out", "", "file to which snapshots are appended")
+	recordCmd.MarkFlagRequired("out")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readHistory returns the snapshots in the file, which may not exist yet.
+func readHistory(t *testing.T, path string) []expr.Snapshot {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	assert.NoError(t, err)
+	defer f.Close()
+	snapshots, err := expr.ReadSnapshots(f)
+	assert.NoError(t, err)
+	return snapshots
+}
+
+// waitForSnapshots waits until the file contains n snapshots.
+func waitForSnapshots(t *testing.T, path string, n int) {
+	assert.Eventually(t, func() bool {
+		return len(readHistory(t, path)) == n
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRecord(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	done := make(chan error)
+	go func() {
+		rootCmd.SetArgs([]string{"record", "-n", "kfserving-test", "sklearn-iris-experiment-1", "--out", path})
+		done <- rootCmd.Execute()
+	}()
+	waitForSnapshots(t, path, 1)
+
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(ctx, key, e))
+	iterations := int32(8)
+	e.Status.CompletedIterations = &iterations
+	assert.NoError(t, rc.Status().Update(ctx, e))
+	waitForSnapshots(t, path, 2)
+
+	// changes other than to the status are not recorded
+	assert.NoError(t, rc.Get(ctx, key, e))
+	e.Labels = map[string]string{"app": "sklearn-iris"}
+	assert.NoError(t, rc.Update(ctx, e))
+
+	assert.NoError(t, rc.Get(ctx, key, e))
+	iterations = 10
+	e.Status.CompletedIterations = &iterations
+	e.Status.MarkCondition(v2alpha2.ExperimentConditionExperimentCompleted, corev1.ConditionTrue, v2alpha2.ReasonExperimentCompleted, "Experiment completed successfully")
+	assert.NoError(t, rc.Status().Update(ctx, e))
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("record did not stop when the experiment completed")
+	}
+	snapshots := readHistory(t, path)
+	assert.Len(t, snapshots, 3)
+	assert.Equal(t, int32(10), *snapshots[2].CompletedIterations)
+	assert.Contains(t, out.String(), "Recorded snapshot of kfserving-test/sklearn-iris-experiment-1 after 7 iterations\n")
+	assert.Contains(t, out.String(), "Experiment kfserving-test/sklearn-iris-experiment-1 completed\n")
+}
+
+func TestRecordNotFound(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient)
+	rootCmd.SetArgs([]string{"record", "missing", "--out", filepath.Join(t.TempDir(), "history.jsonl")})
+	assert.Equal(t, ExitNotFound, exitCode(rootCmd.Execute()))
+}
+
+func TestHistory(t *testing.T) {
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	rootCmd.SetArgs([]string{"history", "-f", "../testdata/history.jsonl"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "****** Winner Changes ******")
+	assert.Contains(t, out.String(), "| mean-latency                 | default | █▅▂▁ ↓ | 240.500 | 228.420 |")
+
+	rootCmd.SetArgs([]string{"history", "-f", "missing.jsonl"})
+	assert.Equal(t, ExitRuntime, exitCode(rootCmd.Execute()))
+}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	expr "github.com/iter8-tools/iter8ctl/experiment"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -13,6 +15,7 @@ var cfgFile string
 var expName string
 var expNamespace string
 var latest bool
+var noColor bool
 var exp *expr.Experiment
 
 // rootCmd represents the base command when called without any subcommands
@@ -20,20 +23,116 @@ var rootCmd = &cobra.Command{
 	Use:   "iter8ctl",
 	Short: "Iter8 command line utility",
 	Long:  `iter8ctl promotes understanding of an Iter8 experiment. It can be used to describe the stage of the experiment, how versions are performing, and assert various conditions relating to the experiment. This program is a K8s client and requires a valid K8s cluster with Iter8 installed.`,
-	Args:  cobra.MaximumNArgs(1),
+	Args:  usageArgs(cobra.MaximumNArgs(1)),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateRequiredFlags(cmd)
+	},
+	// errors are reported by Execute, along with the corresponding exit code
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
 }
 
+// Exit codes of iter8ctl.
+const (
+	// ExitOK implies the command succeeded
+	ExitOK = 0
+	// ExitAssertionFailed implies one or more asserted conditions are not satisfied
+	ExitAssertionFailed = 1
+	// ExitUsage implies the command was invoked incorrectly
+	ExitUsage = 2
+	// ExitNotFound implies the experiment does not exist
+	ExitNotFound = 3
+	// ExitCluster implies the K8s cluster could not be reached, or the request was not authorized
+	ExitCluster = 4
+	// ExitTimeout implies a request to the K8s cluster did not complete in time
+	ExitTimeout = 5
+	// ExitExperimentFailed implies the experiment has failed
+	ExitExperimentFailed = 6
+	// ExitRuntime implies the command failed for another reason; for example, a file could not be read, or a webhook could not be notified
+	ExitRuntime = 7
+)
+
+// exitCode returns the exit code corresponding to err.
+// Errors without a kind are runtime errors; invalid flags and arguments are classified as usage errors where they are detected.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	switch expr.KindOf(err) {
+	case expr.KindAssertionFailed:
+		return ExitAssertionFailed
+	case expr.KindUsage:
+		return ExitUsage
+	case expr.KindNotFound:
+		return ExitNotFound
+	case expr.KindCluster:
+		return ExitCluster
+	case expr.KindTimeout:
+		return ExitTimeout
+	case expr.KindExperimentFailed:
+		return ExitExperimentFailed
+	default:
+		return ExitRuntime
+	}
+}
+
+// usageError classifies err as a usage error, unless it is nil or already classified.
+func usageError(err error) error {
+	if err == nil || expr.KindOf(err) != "" {
+		return err
+	}
+	return &expr.Error{Kind: expr.KindUsage, Err: err}
+}
+
+// usageArgs returns the positional argument validator which classifies the errors of validate as usage errors.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		return usageError(validate(cmd, args))
+	}
+}
+
+// validateRequiredFlags returns a usage error if a flag of cmd marked as required is not set.
+// It precedes the equivalent validation by cobra, whose errors are not classified.
+func validateRequiredFlags(cmd *cobra.Command) error {
+	var missing []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if required := f.Annotations[cobra.BashCompOneRequiredFlag]; len(required) > 0 && required[0] == "true" && !f.Changed {
+			missing = append(missing, f.Name)
+		}
+	})
+	if len(missing) > 0 {
+		return expr.NewError(expr.KindUsage, fmt.Sprintf(`required flag(s) "%s" not set`, strings.Join(missing, `", "`)))
+	}
+	return nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// On error, it exits with the exit code corresponding to the error.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	c, err := rootCmd.ExecuteC()
+	if err == nil {
+		return
+	}
+	code := exitCode(err)
+	// the assertion checklist has already been printed
+	if code != ExitAssertionFailed {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	if code == ExitUsage {
+		fmt.Fprintf(os.Stderr, "Run '%v --help' for usage.\n", c.CommandPath())
+	}
+	os.Exit(code)
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(err)
+	})
 
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
@@ -42,6 +141,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.iter8ctl.yaml)")
 
 	rootCmd.PersistentFlags().StringVarP(&expNamespace, "namespace", "n", "default", "namespace of the experiment; ignored when experiment name is not specified explicitly")
+
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output; color is also disabled when the NO_COLOR environment variable is set, or when output is not a terminal")
 }
 
 // initConfig reads in config file and ENV variables if set.
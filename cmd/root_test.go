@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, exitCode(nil))
+	assert.Equal(t, ExitRuntime, exitCode(errors.New("open history.jsonl: no such file or directory")))
+	for kind, code := range map[expr.ErrorKind]int{
+		expr.KindAssertionFailed:  ExitAssertionFailed,
+		expr.KindUsage:            ExitUsage,
+		expr.KindNotFound:         ExitNotFound,
+		expr.KindCluster:          ExitCluster,
+		expr.KindTimeout:          ExitTimeout,
+		expr.KindExperimentFailed: ExitExperimentFailed,
+	} {
+		assert.Equal(t, code, exitCode(expr.NewError(kind, "oops")), kind)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	rootCmd.SetArgs([]string{"describe", "--foo"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "unknown flag: --foo")
+	assert.Equal(t, ExitUsage, exitCode(err))
+
+	rootCmd.SetArgs([]string{"history", "extra"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+
+	rootCmd.SetArgs([]string{"record"})
+	assert.Equal(t, ExitUsage, exitCode(rootCmd.Execute()))
+
+	c := &cobra.Command{}
+	c.Flags().String("file", "", "")
+	assert.NoError(t, c.MarkFlagRequired("file"))
+	err = validateRequiredFlags(c)
+	assert.EqualError(t, err, `required flag(s) "file" not set`)
+	assert.Equal(t, ExitUsage, exitCode(err))
+	assert.NoError(t, c.Flags().Set("file", "history.jsonl"))
+	assert.NoError(t, validateRequiredFlags(c))
+}
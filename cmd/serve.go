@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/iter8-tools/iter8ctl/server"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+// shutdownTimeout is the time allowed for in-flight requests to complete when the server is stopped.
+const shutdownTimeout = 5 * time.Second
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the status of Iter8 experiments over HTTP",
+	Long: `Serve the status of experiments in the cluster as JSON over HTTP. Experiments are served from an in-memory cache, which is kept up to date by watching the cluster. When --namespace is specified, only experiments in that namespace are served. The following endpoints are available.
+
+  GET /experiments[?namespace=<namespace>]
+      the structured description of every experiment, as with 'iter8ctl describe -o json'
+  GET /experiments/<namespace>/<name>
+      the structured description of the experiment
+  GET /experiments/<namespace>/<name>/assert?condition=<condition>
+      whether or not the experiment satisfies the conditions, as with 'iter8ctl assert -c <condition>'
+  GET /metrics
+      gauges describing every experiment, in the Prometheus exposition format; see 'iter8ctl export-metrics --help'`,
+	Example: `  iter8ctl serve --addr :8080
+  curl localhost:8080/experiments/my-namespace/my-experiment/assert?condition=completed,winnerFound`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace := ""
+		if cmd.Flags().Changed("namespace") {
+			namespace = expNamespace
+		}
+		ctx, cancel := interruptContext()
+		defer cancel()
+		cache := server.NewCache()
+		cacheErrs, err := cache.Start(ctx, namespace)
+		if err != nil {
+			return err
+		}
+
+		l, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: server.NewHandler(cache)}
+		serveErrs := make(chan error, 1)
+		go func() {
+			serveErrs <- srv.Serve(l)
+		}()
+		fmt.Fprintf(cmd.OutOrStdout(), "Serving experiments on %s\n", l.Addr())
+
+		select {
+		case err = <-serveErrs:
+			return err
+		case err = <-cacheErrs:
+		case <-ctx.Done():
+		}
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelShutdown()
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil && !errors.Is(shutdownErr, http.ErrServerClosed) {
+			return shutdownErr
+		}
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "TCP address on which to serve, in the form host:port")
+}
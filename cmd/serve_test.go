@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeInvalidAddr(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml")
+	rootCmd.SetArgs([]string{"serve", "--addr", "invalid"})
+	assert.Error(t, rootCmd.Execute())
+	serveAddr = ":8080"
+}
+
+func TestExportMetrics(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml", "experiment12.yaml")
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"export-metrics", "-n", "kfserving-test"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, buf.String(), `iter8_experiment_winner{experiment="sklearn-iris-experiment-1",namespace="kfserving-test",version="canary"} 1`)
+	assert.NotContains(t, buf.String(), "istio-quickstart")
+	expNamespace = "default"
+}
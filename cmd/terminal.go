@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// terminalWidth returns the width of the terminal to which w writes, or 0 if w is not a terminal.
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// colorEnabled indicates if output written to w is to be colored.
+// Color is enabled only for terminals, and is disabled by the --no-color flag, or by setting the NO_COLOR environment variable (https://no-color.org).
+func colorEnabled(w io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return terminalWidth(w) > 0
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/top"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to manage the screen of the dashboard.
+const (
+	enterAltScreen = "\033[?1049h\033[?25l"
+	exitAltScreen  = "\033[?25h\033[?1049l"
+	clearScreen    = "\033[H\033[2J"
+)
+
+// refreshInterval is the interval at which the dashboard is redrawn in the absence of events, so that it fits a resized terminal.
+const refreshInterval = time.Second
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Display a live dashboard of Iter8 experiments",
+	Long:  `Display a full-screen, live-updating dashboard of the experiments in the cluster. Select an experiment to view its winner, traffic split, objectives, and trends of its metrics over the session. Press n to cycle through namespaces, s to cycle through stages, and q to quit. When --namespace is specified, the dashboard starts with that namespace selected.`,
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, out := os.Stdin, cmd.OutOrStdout()
+		if !term.IsTerminal(int(in.Fd())) || terminalWidth(out) == 0 {
+			return errors.New("top requires an interactive terminal")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := expr.WatchExperiments(ctx, "")
+		if err != nil {
+			return err
+		}
+
+		state, err := term.MakeRaw(int(in.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(in.Fd()), state)
+		fmt.Fprint(out, enterAltScreen)
+		defer fmt.Fprint(out, exitAltScreen)
+
+		m := top.NewModel()
+		if cmd.Flags().Changed("namespace") {
+			m.SetNamespace(expNamespace)
+		}
+		keys := readKeys(in)
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for !m.Quit() {
+			width, height, _ := term.GetSize(int(in.Fd()))
+			// in raw mode, a newline does not return the cursor to the start of the line
+			fmt.Fprint(out, clearScreen+strings.ReplaceAll(m.View(width, height), "\n", "\r\n"))
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return expr.NewError(expr.KindCluster, "watch of experiments ended")
+				}
+				m.Apply(ev)
+			case k, ok := <-keys:
+				if !ok {
+					return nil
+				}
+				m.HandleKey(k)
+			case <-ticker.C:
+			}
+		}
+		return nil
+	},
+}
+
+// readKeys reads key presses from r, and sends the corresponding dashboard keys; unrecognized keys are ignored.
+// The returned channel is closed when r can no longer be read.
+func readKeys(r io.Reader) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 8)
+		for {
+			n, err := r.Read(buf)
+			if err != nil {
+				return
+			}
+			if k := parseKey(buf[:n]); k != "" {
+				keys <- k
+			}
+		}
+	}()
+	return keys
+}
+
+// parseKey returns the dashboard key corresponding to the bytes read from a terminal in raw mode, or the empty string if there is none.
+func parseKey(b []byte) string {
+	switch string(b) {
+	case "\033[A", "k":
+		return top.KeyUp
+	case "\033[B", "j":
+		return top.KeyDown
+	case "\r", "\n":
+		return top.KeyEnter
+	case "\033", "\x7f", "\b":
+		return top.KeyEscape
+	case "n":
+		return top.KeyNamespace
+	case "s":
+		return top.KeyStage
+	case "q", "\x03":
+		return top.KeyQuit
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/iter8-tools/iter8ctl/top"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKey(t *testing.T) {
+	assert.Equal(t, top.KeyUp, parseKey([]byte("\033[A")))
+	assert.Equal(t, top.KeyDown, parseKey([]byte("j")))
+	assert.Equal(t, top.KeyEnter, parseKey([]byte("\r")))
+	assert.Equal(t, top.KeyEscape, parseKey([]byte("\033")))
+	assert.Equal(t, top.KeyQuit, parseKey([]byte{3}))
+	assert.Equal(t, "", parseKey([]byte("x")))
+}
+
+func TestTopRequiresTerminal(t *testing.T) {
+	rootCmd.SetArgs([]string{"top"})
+	err := rootCmd.Execute()
+	assert.EqualError(t, err, "top requires an interactive terminal")
+	assert.Equal(t, ExitRuntime, exitCode(err))
+}
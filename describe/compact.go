@@ -0,0 +1,78 @@
+package describe
+
+import (
+	"fmt"
+	"strings"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+)
+
+// printCompact prints a summary of the experiment into d's description buffer, consisting of one line for the experiment followed by one line per version.
+// For example,
+//  default/istio-quickstart: stage Completed, 10 iterations, winner B
+//  A: objectives 2/2, books-purchased 5.030
+//  B (winner, recommended): objectives 2/2, books-purchased 24.454 *
+func (d *Result) printCompact() *Result {
+	if d.err != nil {
+		return d
+	}
+	stage := "unavailable"
+	if d.experiment.Status.Stage != nil {
+		stage = string(*d.experiment.Status.Stage)
+	}
+	var iterations int32
+	if d.experiment.Status.CompletedIterations != nil {
+		iterations = *d.experiment.Status.CompletedIterations
+	}
+	d.description.WriteString(fmt.Sprintf("%s/%s: stage %s, %v iterations, winner %s\n",
+		d.experiment.Namespace, d.experiment.Name, stage, iterations, d.winnerStr()))
+
+	for i, version := range d.analysis.Versions {
+		var roles []string
+		if d.analysis.Winner != nil && *d.analysis.Winner == version {
+			roles = append(roles, "winner")
+		}
+		if d.analysis.Recommended != nil && *d.analysis.Recommended == version {
+			roles = append(roles, "recommended")
+		}
+		line := version
+		if len(roles) > 0 {
+			line += " (" + strings.Join(roles, ", ") + ")"
+		}
+
+		var facts []string
+		if len(d.analysis.Objectives) > 0 {
+			facts = append(facts, objectivesStr(d.analysis.Objectives, i))
+		}
+		for _, ra := range d.analysis.Rewards {
+			s := ra.Reward.Metric + " " + formatDec(ra.Values[i].Value)
+			if ra.Values[i].Available() && ra.Best() == version {
+				s += " *"
+			}
+			facts = append(facts, s)
+		}
+		if len(facts) > 0 {
+			line += ": " + strings.Join(facts, ", ")
+		}
+		d.description.WriteString(line + "\n")
+	}
+	return d
+}
+
+// objectivesStr returns the number of objectives satisfied by the version with the given index, out of the number of objectives.
+func objectivesStr(objectives []expr.ObjectiveAnalysis, versionIndex int) string {
+	satisfied, unknown := 0, 0
+	for _, oa := range objectives {
+		switch oa.Satisfaction[versionIndex] {
+		case expr.Satisfied:
+			satisfied++
+		case expr.SatisfactionUnknown:
+			unknown++
+		}
+	}
+	s := fmt.Sprintf("objectives %v/%v", satisfied, len(objectives))
+	if unknown > 0 {
+		s += fmt.Sprintf(" (%v unavailable)", unknown)
+	}
+	return s
+}
@@ -2,19 +2,73 @@
 package describe
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/iter8-tools/etc3/api/v2alpha2"
 	"github.com/iter8-tools/handler/tasks"
 	expr "github.com/iter8-tools/iter8ctl/experiment"
-	"github.com/olekukonko/tablewriter"
+	"gopkg.in/inf.v0"
 )
 
+// Format is an output format of the description.
+type Format string
+
+const (
+	// FormatText is the human readable format with one table per assessment
+	FormatText Format = "text"
+	// FormatCompact is the human readable format with a one line summary per version
+	FormatCompact Format = "compact"
+)
+
+// Section is a section of the description in text format.
+type Section string
+
+const (
+	// SectionOverview contains the name, namespace, target, and testing pattern of the experiment
+	SectionOverview Section = "overview"
+	// SectionProgress contains the stage and number of completed iterations of the experiment
+	SectionProgress Section = "progress"
+	// SectionWinner contains the winner assessment
+	SectionWinner Section = "winner"
+	// SectionRewards contains the reward assessment
+	SectionRewards Section = "rewards"
+	// SectionObjectives contains the objective assessment
+	SectionObjectives Section = "objectives"
+	// SectionMetrics contains the metrics assessment
+	SectionMetrics Section = "metrics"
+	// SectionGates contains the decisions of CI/CD gates recorded on the experiment by 'iter8ctl annotate'
+	SectionGates Section = "gates"
+)
+
+// AllSections lists every section in the order in which they are rendered.
+var AllSections = []Section{SectionOverview, SectionProgress, SectionWinner, SectionRewards, SectionObjectives, SectionMetrics, SectionGates}
+
+// ParseSection returns the section with the given name.
+func ParseSection(s string) (Section, error) {
+	for _, section := range AllSections {
+		if string(section) == s {
+			return section, nil
+		}
+	}
+	names := make([]string, len(AllSections))
+	for i, section := range AllSections {
+		names[i] = string(section)
+	}
+	return "", fmt.Errorf("invalid section: %v; expected one of %v", s, strings.Join(names, ", "))
+}
+
 // Result struct contains fields that store intermediate results associated with an invocation of 'iter8ctl describe' subcommand.
 type Result struct {
 	experiment  *expr.Experiment
+	analysis    *expr.Analysis
+	sections    map[Section]bool
+	width       int
+	color       bool
+	history     []expr.Snapshot
 	description strings.Builder
 	err         error
 }
@@ -41,9 +95,33 @@ func (d *Result) WithExperiment(exp *expr.Experiment) *Result {
 		return d
 	}
 	d.experiment = exp
+	if exp != nil {
+		d.analysis = exp.GetAnalysis()
+	}
+	return d
+}
+
+// WithSections restricts the description in text format to the given sections, which are rendered in the order of AllSections.
+// Every section is rendered if no sections are given.
+func (d *Result) WithSections(sections ...Section) *Result {
+	if d.err != nil {
+		return d
+	}
+	d.sections = nil
+	if len(sections) > 0 {
+		d.sections = map[Section]bool{}
+		for _, s := range sections {
+			d.sections[s] = true
+		}
+	}
 	return d
 }
 
+// includes indicates if the section is to be rendered.
+func (d *Result) includes(s Section) bool {
+	return d.sections == nil || d.sections[s]
+}
+
 // FromFile populates the Result struct with an experiment from file.
 func (d *Result) FromFile(path string) *Result {
 	if d.err != nil {
@@ -54,12 +132,11 @@ func (d *Result) FromFile(path string) *Result {
 		d.err = err
 		return d
 	}
-	d.experiment = &expr.Experiment{Experiment: exp.Experiment}
-	return d
+	return d.WithExperiment(&expr.Experiment{Experiment: exp.Experiment})
 }
 
-// printProgress prints name, namespace, and target of the experiment and the number of completed iterations into d's description buffer.
-func (d *Result) printProgress() *Result {
+// printOverview prints name, namespace, target, and testing and deployment patterns of the experiment into d's description buffer.
+func (d *Result) printOverview() *Result {
 	if d.err != nil {
 		return d
 	}
@@ -73,7 +150,14 @@ func (d *Result) printProgress() *Result {
 		deploymentPattern = *d.experiment.Spec.Strategy.DeploymentPattern
 	}
 	d.description.WriteString(fmt.Sprintf("Deployment pattern: %v\n", deploymentPattern))
+	return d
+}
 
+// printProgress prints the stage of the experiment and the number of completed iterations into d's description buffer.
+func (d *Result) printProgress() *Result {
+	if d.err != nil {
+		return d
+	}
 	d.description.WriteString("\n****** Progress Summary ******\n")
 	sta := d.experiment.Status
 	if sta.Stage != nil {
@@ -93,65 +177,71 @@ func (d *Result) printWinnerAssessment() *Result {
 	if d.err != nil {
 		return d
 	}
-	if a := d.experiment.Status.Analysis; a != nil {
-		if w := a.WinnerAssessment; w != nil {
-			d.description.WriteString("\n****** Winner Assessment ******\n")
-			var explanation string = ""
-			switch d.experiment.Spec.Strategy.TestingPattern {
-			case v2alpha2.TestingPatternCanary:
-				explanation = "> If the candidate version satisfies the experiment objectives, then it is the winner.\n> Otherwise, if the baseline version satisfies the experiment objectives, it is the winner.\n> Otherwise, there is no winner.\n"
-			case v2alpha2.TestingPatternConformance:
-				explanation = "> If the version being validated; i.e., the baseline version, satisfies the experiment objectives, it is the winner.\n> Otherwise, there is no winner.\n"
-			default:
-				explanation = ""
-			}
-			d.description.WriteString(explanation)
-			if d.experiment.Spec.Strategy.TestingPattern != v2alpha2.TestingPatternConformance && d.experiment.Spec.VersionInfo != nil {
-				versions := []string{d.experiment.Spec.VersionInfo.Baseline.Name}
-				for i := 0; i < len(d.experiment.Spec.VersionInfo.Candidates); i++ {
-					versions = append(versions, d.experiment.Spec.VersionInfo.Candidates[i].Name)
-				}
-				d.description.WriteString(fmt.Sprintf("App versions in this experiment: %s\n", versions))
-			}
-			if w.Data.WinnerFound {
-				d.description.WriteString(fmt.Sprintf("Winning version: %s\n", *w.Data.Winner))
-			} else {
-				d.description.WriteString("Winning version: not found\n")
-			}
+	d.description.WriteString("\n****** Winner Assessment ******\n")
+	var explanation string = ""
+	switch d.experiment.Spec.Strategy.TestingPattern {
+	case v2alpha2.TestingPatternCanary:
+		explanation = "> If the candidate version satisfies the experiment objectives, then it is the winner.\n> Otherwise, if the baseline version satisfies the experiment objectives, it is the winner.\n> Otherwise, there is no winner.\n"
+	case v2alpha2.TestingPatternConformance:
+		explanation = "> If the version being validated; i.e., the baseline version, satisfies the experiment objectives, it is the winner.\n> Otherwise, there is no winner.\n"
+	default:
+		explanation = ""
+	}
+	d.description.WriteString(explanation)
+	if d.experiment.Spec.Strategy.TestingPattern != v2alpha2.TestingPatternConformance && d.experiment.Spec.VersionInfo != nil {
+		d.description.WriteString(fmt.Sprintf("App versions in this experiment: %s\n", d.analysis.Versions))
+	}
 
-			if d.experiment.Spec.Strategy.TestingPattern != v2alpha2.TestingPatternConformance &&
-				d.experiment.Status.VersionRecommendedForPromotion != nil {
-				d.description.WriteString(fmt.Sprintf("Version recommended for promotion: %s\n", *d.experiment.Status.VersionRecommendedForPromotion))
-			}
-		}
+	winner := d.winnerStr()
+	if d.analysis.Winner != nil && winner == *d.analysis.Winner {
+		winner = d.colorize(winner, colorBold+colorGreen)
+	}
+	d.description.WriteString("Winning version: " + winner + "\n")
+
+	if d.experiment.Spec.Strategy.TestingPattern != v2alpha2.TestingPatternConformance &&
+		d.analysis.Recommended != nil {
+		d.description.WriteString(fmt.Sprintf("Version recommended for promotion: %s\n", *d.analysis.Recommended))
 	}
 	return d
 }
 
+// winnerStr returns the winning version, "not found", or "unavailable".
+func (d *Result) winnerStr() string {
+	switch {
+	case !d.analysis.WinnerAssessed || (d.analysis.WinnerFound && d.analysis.Winner == nil):
+		return "unavailable"
+	case !d.analysis.WinnerFound:
+		return "not found"
+	default:
+		return *d.analysis.Winner
+	}
+}
+
 // printRewardAssessment prints a matrix of values for each reward-version pair.
 // Rows correspond to experiment rewards. Columns correspond to versions.
-// The current "best" version for each reward is denoted with a "*".
+// The current "best" version for each reward is denoted with a "*"; unavailable values are indicated likewise.
 func (d *Result) printRewardAssessment() *Result {
-	if d.err != nil ||
-		d.experiment.Status.Analysis == nil ||
-		d.experiment.Status.Analysis.VersionAssessments == nil ||
-		d.experiment.Spec.Criteria == nil ||
-		len(d.experiment.Spec.Criteria.Rewards) == 0 {
+	if d.err != nil || len(d.analysis.Rewards) == 0 {
 		return d
 	}
 
 	d.description.WriteString("\n****** Reward Assessment ******\n")
 	d.description.WriteString("> Identifies values of reward metrics for each version. The best version is marked with a '*'.\n")
-	table := tablewriter.NewWriter(&d.description)
-	table.SetRowLine(true)
-	versions := d.experiment.GetVersions()
-	table.SetHeader(append([]string{"Reward"}, versions...))
-	for _, reward := range d.experiment.Spec.Criteria.Rewards {
-		row := []string{expr.StringifyReward(reward)}
-		table.Append(append(row, d.experiment.GetAnnotatedMetricStrs(reward)...))
+	var labels []string
+	var cells [][]string
+	for _, ra := range d.analysis.Rewards {
+		labels = append(labels, expr.StringifyReward(ra.Reward))
+		var row []string
+		for _, v := range ra.Values {
+			s := formatDec(v.Value)
+			if v.Available() && v.Version == ra.Best() {
+				s = d.colorize(s+" *", colorBold+colorGreen)
+			}
+			row = append(row, d.colorizeValue(s))
+		}
+		cells = append(cells, row)
 	}
-	table.Render()
-
+	d.printMatrix("Reward", labels, cells)
 	return d
 }
 
@@ -160,24 +250,29 @@ func (d *Result) printRewardAssessment() *Result {
 // Objective assessments are printed in the same sequence as in the experiment's spec.criteria.objectives section.
 // If objective assessments are unavailable for the underlying experiment, this method will indicate likewise.
 func (d *Result) printObjectiveAssessment() *Result {
-	if d.err != nil {
+	if d.err != nil || len(d.analysis.Objectives) == 0 {
 		return d
 	}
-	if a := d.experiment.Status.Analysis; a != nil {
-		if v := a.VersionAssessments; v != nil {
-			d.description.WriteString("\n****** Objective Assessment ******\n")
-			d.description.WriteString("> Identifies whether or not the experiment objectives are satisfied by the most recently observed metrics values for each version.\n")
-			table := tablewriter.NewWriter(&d.description)
-			table.SetRowLine(true)
-			versions := d.experiment.GetVersions()
-			table.SetHeader(append([]string{"Objective"}, versions...))
-			for i, objective := range d.experiment.Spec.Criteria.Objectives {
-				row := []string{expr.StringifyObjective(objective)}
-				table.Append(append(row, d.experiment.GetSatisfyStrs(i)...))
+	d.description.WriteString("\n****** Objective Assessment ******\n")
+	d.description.WriteString("> Identifies whether or not the experiment objectives are satisfied by the most recently observed metrics values for each version.\n")
+	var labels []string
+	var cells [][]string
+	for _, oa := range d.analysis.Objectives {
+		labels = append(labels, expr.StringifyObjective(oa.Objective))
+		var row []string
+		for _, s := range oa.Satisfaction {
+			switch s {
+			case expr.Satisfied:
+				row = append(row, d.colorize(s.String(), colorGreen))
+			case expr.NotSatisfied:
+				row = append(row, d.colorize(s.String(), colorRed))
+			default:
+				row = append(row, d.colorizeValue(s.String()))
 			}
-			table.Render()
 		}
+		cells = append(cells, row)
 	}
+	d.printMatrix("Objective", labels, cells)
 	return d
 }
 
@@ -186,7 +281,7 @@ func (d *Result) printVersionAssessment() *Result {
 	if d.err != nil {
 		return d
 	}
-	if c := d.experiment.Spec.Criteria; c != nil && len(c.Objectives) > 0 {
+	if len(d.analysis.Objectives) > 0 {
 		d.printObjectiveAssessment()
 	}
 	return d
@@ -194,44 +289,115 @@ func (d *Result) printVersionAssessment() *Result {
 
 // printMetrics prints a matrix of (decimal) metric values into d's description buffer.
 // Rows correspond to experiment metrics, columns correspond to versions, and entry [i, j] indicates the value of metric i for version j.
+// If a history of the experiment is given using WithHistory, each value is followed by a sparkline of the values of the metric across the history, and an arrow indicating the direction of its last change.
 // Metrics are printed in the same sequence as in the experiment's status.metrics section.
 // If metrics are unavailable for the underlying experiment, this method will indicate likewise.
 func (d *Result) printMetrics() *Result {
-	if d.err != nil {
+	if d.err != nil || len(d.analysis.Metrics) == 0 {
 		return d
 	}
-	if a := d.experiment.Status.Analysis; a != nil {
-		if v := a.AggregatedMetrics; v != nil {
-			d.description.WriteString("\n****** Metrics Assessment ******\n")
-			d.description.WriteString("> Most recently read values of experiment metrics for each version.\n")
-			table := tablewriter.NewWriter(&d.description)
-			table.SetRowLine(true)
-			versions := d.experiment.GetVersions()
-			table.SetHeader(append([]string{"Metric"}, versions...))
-			for _, metricInfo := range d.experiment.Status.Metrics {
-				row := []string{expr.GetMetricNameAndUnits(metricInfo)}
-				table.Append(append(row, d.experiment.GetMetricStrs(metricInfo.Name)...))
+	d.description.WriteString("\n****** Metrics Assessment ******\n")
+	history := d.experimentHistory()
+	if len(history) > 1 {
+		d.description.WriteString(fmt.Sprintf("> Most recently read values of experiment metrics for each version, followed by their trend across %d snapshots and the direction of the last change.\n", len(history)))
+	} else {
+		d.description.WriteString("> Most recently read values of experiment metrics for each version.\n")
+	}
+	var labels []string
+	var cells [][]string
+	for _, ma := range d.analysis.Metrics {
+		name := ma.Name
+		if ma.Units != "" {
+			name += " (" + ma.Units + ")"
+		}
+		labels = append(labels, name)
+		var row []string
+		for _, v := range ma.Values {
+			cell := d.colorizeValue(formatDec(v.Value))
+			if len(history) > 1 {
+				if t := trendStr(metricHistory(history, ma.Name, v.Version)); t != "" {
+					cell += " " + t
+				}
 			}
-			table.Render()
+			row = append(row, cell)
 		}
+		cells = append(cells, row)
 	}
+	d.printMatrix("Metric", labels, cells)
 	return d
 }
 
-// PrintAnalysis prints the progress of the iter8 experiment, winner assessment, version assessment, and metrics.
-func (d *Result) PrintAnalysis() *Result {
+// formatDec returns x rounded to 3 decimal places, or "unavailable" if x is nil.
+func formatDec(x *inf.Dec) string {
+	if x == nil {
+		return "unavailable"
+	}
+	return new(inf.Dec).Round(x, 3, inf.RoundCeil).String()
+}
+
+// Render writes the description of the experiment to w in the given format.
+// The json, yaml, go-template, and jsonpath formats render the Report of the experiment. In text format, the description includes the progress of the iter8 experiment, winner assessment, version assessment, metrics, and gate history, restricted to the sections selected using WithSections.
+// Values missing from a partially populated experiment are rendered as unavailable.
+func (d *Result) Render(w io.Writer, format Format) *Result {
 	if d.err != nil {
 		return d
 	}
-	d.printProgress()
-	if d.experiment.Started() {
-		d.printWinnerAssessment().
-			printRewardAssessment().
-			printVersionAssessment().
-			printMetrics()
+	if d.experiment == nil {
+		d.err = errors.New("no experiment to describe")
+		return d
+	}
+	if isReportFormat(format) {
+		return d.renderReport(w, format)
+	}
+	d.description.Reset()
+	switch format {
+	case FormatText:
+		d.printText()
+	case FormatCompact:
+		d.printCompact()
+	default:
+		d.err = expr.NewError(expr.KindUsage, fmt.Sprintf("invalid output format: %v; expected one of %v, %v, %v, %v, go-template=..., jsonpath=...", format, FormatText, FormatCompact, FormatJSON, FormatYAML))
+		return d
 	}
 	if d.err == nil {
-		fmt.Fprintln(os.Stdout, d.description.String())
+		out := d.description.String()
+		if format == FormatText {
+			out += "\n"
+		}
+		_, d.err = io.WriteString(w, out)
+	}
+	return d
+}
+
+// printText prints the selected sections into d's description buffer.
+func (d *Result) printText() *Result {
+	if d.includes(SectionOverview) {
+		d.printOverview()
+	}
+	if d.includes(SectionProgress) {
+		d.printProgress()
+	}
+	if d.experiment.Started() {
+		if d.includes(SectionWinner) {
+			d.printWinnerAssessment()
+		}
+		if d.includes(SectionRewards) {
+			d.printRewardAssessment()
+		}
+		if d.includes(SectionObjectives) {
+			d.printVersionAssessment()
+		}
+		if d.includes(SectionMetrics) {
+			d.printMetrics()
+		}
+	}
+	if d.includes(SectionGates) {
+		d.printGateHistory()
 	}
 	return d
 }
+
+// PrintAnalysis prints the description of the experiment to os.Stdout in text format.
+func (d *Result) PrintAnalysis() *Result {
+	return d.Render(os.Stdout, FormatText)
+}
@@ -1,9 +1,17 @@
 package describe
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/ghodss/yaml"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
 	"github.com/iter8-tools/iter8ctl/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -65,3 +73,141 @@ func TestPrintAnalysis(t *testing.T) {
 		assert.NoError(t, d.Error())
 	}
 }
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	assert.Contains(t, buf.String(), "Experiment name: sklearn-iris-experiment-1")
+	assert.Contains(t, buf.String(), "****** Metrics Assessment ******")
+
+	// rendering again does not repeat the description
+	buf.Reset()
+	d.Render(buf, FormatText)
+	assert.Equal(t, 1, strings.Count(buf.String(), "****** Overview ******"))
+
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, "xml")
+	assert.EqualError(t, d.Error(), "invalid output format: xml; expected one of text, compact, json, yaml, go-template=..., jsonpath=...")
+
+	d = Builder().Render(buf, FormatText)
+	assert.Error(t, d.Error())
+}
+
+func TestRenderSections(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml")).WithSections(SectionMetrics, SectionOverview).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	assert.Contains(t, buf.String(), "****** Overview ******")
+	assert.Contains(t, buf.String(), "****** Metrics Assessment ******")
+	assert.NotContains(t, buf.String(), "****** Progress Summary ******")
+	assert.NotContains(t, buf.String(), "****** Winner Assessment ******")
+	// sections are rendered in a fixed order
+	assert.Less(t, strings.Index(buf.String(), "Overview"), strings.Index(buf.String(), "Metrics Assessment"))
+
+	_, err := ParseSection("winner")
+	assert.NoError(t, err)
+	_, err = ParseSection("winners")
+	assert.EqualError(t, err, "invalid section: winners; expected one of overview, progress, winner, rewards, objectives, metrics, gates")
+}
+
+func TestRenderCompact(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml")).Render(buf, FormatCompact)
+	assert.NoError(t, d.Error())
+	assert.Equal(t, `default/istio-quickstart: stage Completed, 10 iterations, winner B
+A: objectives 2/2, books-purchased 5.030
+B (winner, recommended): objectives 2/2, books-purchased 24.454 *
+`, buf.String())
+
+	buf.Reset()
+	exp := d.experiment
+	exp.Status.Analysis.VersionAssessments.Data["A"] = []bool{false}
+	exp.Status.Analysis.WinnerAssessment = nil
+	d = Builder().WithExperiment(exp).Render(buf, FormatCompact)
+	assert.NoError(t, d.Error())
+	assert.Contains(t, buf.String(), "winner unavailable")
+	assert.Contains(t, buf.String(), "A: objectives 0/2 (1 unavailable)")
+}
+
+// prune returns v after removing each map entry and list element below it with probability p, and records the paths of removed values.
+func prune(r *rand.Rand, v interface{}, p float64, path string, pruned *[]string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if r.Float64() < p {
+				delete(val, k)
+				*pruned = append(*pruned, path+"."+k)
+				continue
+			}
+			val[k] = prune(r, val[k], p, path+"."+k, pruned)
+		}
+		return val
+	case []interface{}:
+		kept := []interface{}{}
+		for i, elem := range val {
+			if r.Float64() < p {
+				*pruned = append(*pruned, fmt.Sprintf("%v[%v]", path, i))
+				continue
+			}
+			kept = append(kept, prune(r, elem, p, fmt.Sprintf("%v[%v]", path, i), pruned))
+		}
+		return kept
+	default:
+		return v
+	}
+}
+
+// TestPrintAnalysisPrunedExperiments describes randomly pruned copies of the testdata experiments, none of which may cause a panic.
+func TestPrintAnalysisPrunedExperiments(t *testing.T) {
+	t.Parallel()
+	const seed = 8
+	r := rand.New(rand.NewSource(seed))
+	for i := 1; i <= 12; i++ {
+		buf, err := ioutil.ReadFile(utils.CompletePath("../", fmt.Sprintf("testdata/experiment%v.yaml", i)))
+		assert.NoError(t, err)
+		j, err := yaml.YAMLToJSON(buf)
+		assert.NoError(t, err)
+
+		for n := 0; n < 100; n++ {
+			var doc interface{}
+			assert.NoError(t, json.Unmarshal(j, &doc))
+			var pruned []string
+			doc = prune(r, doc, 0.1, "", &pruned)
+			b, err := json.Marshal(doc)
+			assert.NoError(t, err)
+			exp := &expr.Experiment{}
+			if err := json.Unmarshal(b, &exp.Experiment); err != nil {
+				continue
+			}
+
+			func() {
+				defer func() {
+					if p := recover(); p != nil {
+						t.Fatalf("panic on experiment%v with seed %v after pruning %v: %v", i, seed, pruned, p)
+					}
+				}()
+				d := Builder().WithExperiment(exp).Render(ioutil.Discard, FormatText)
+				assert.NoError(t, d.Error())
+
+				exp.Assert([]expr.ConditionType{expr.Completed, expr.WinnerFound})
+				exp.AssertWinner(expr.CandidateRole)
+				exp.AssertRecommended(expr.BaselineRole)
+				exp.AssertObjectivesSatisfied(expr.CandidateRole)
+				exp.AssertMinIterations(1)
+				exp.AssertMinSampleSize(1)
+				exp.ExpressionView()
+				for _, metric := range exp.GetMetricNames() {
+					exp.GetMetricStrs(metric)
+				}
+			}()
+		}
+	}
+}
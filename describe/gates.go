@@ -0,0 +1,35 @@
+package describe
+
+import (
+	"time"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+)
+
+// printGateHistory prints a table of the decisions of CI/CD gates recorded on the experiment, in the order in which they were made, into d's description buffer.
+// Nothing is printed if no decisions are recorded.
+func (d *Result) printGateHistory() *Result {
+	decisions := d.experiment.GetGateDecisions()
+	if d.err != nil || len(decisions) == 0 {
+		return d
+	}
+	d.description.WriteString("\n****** Gate History ******\n")
+	d.description.WriteString("> Decisions of CI/CD gates recorded on the experiment using 'iter8ctl annotate'. Only the latest decision of each gate is recorded.\n")
+	var rows [][]string
+	for _, g := range decisions {
+		result := string(g.Result)
+		switch g.Result {
+		case expr.GatePassed:
+			result = d.colorize(result, colorGreen)
+		case expr.GateFailed:
+			result = d.colorize(result, colorRed)
+		}
+		pipelineRun := g.PipelineRun
+		if pipelineRun == "" {
+			pipelineRun = d.colorizeValue("unavailable")
+		}
+		rows = append(rows, []string{g.Gate, result, g.Time.UTC().Format(time.RFC3339), pipelineRun})
+	}
+	d.printTable([]string{"Gate", "Result", "Time", "Pipeline Run"}, rows)
+	return d
+}
@@ -0,0 +1,55 @@
+package describe
+
+import (
+	"bytes"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// withGateDecisions returns a Result for experiment8 with two gate decisions recorded on it.
+func withGateDecisions(t *testing.T) *Result {
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml"))
+	assert.NoError(t, d.Error())
+	d.experiment.Annotations = map[string]string{
+		expr.GateAnnotationPrefix + "smoke":  `{"result":"failed","time":"2021-06-01T13:00:00Z"}`,
+		expr.GateAnnotationPrefix + "canary": `{"result":"passed","time":"2021-06-01T12:00:00Z","pipelineRun":"https://ci.example.com/runs/42"}`,
+	}
+	return d
+}
+
+func TestRenderGateHistory(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := withGateDecisions(t).WithSections(SectionGates).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	assert.Equal(t, `
+****** Gate History ******
+> Decisions of CI/CD gates recorded on the experiment using 'iter8ctl annotate'. Only the latest decision of each gate is recorded.
++--------+--------+----------------------+--------------------------------+
+|  GATE  | RESULT |         TIME         |          PIPELINE RUN          |
++--------+--------+----------------------+--------------------------------+
+| canary | passed | 2021-06-01T12:00:00Z | https://ci.example.com/runs/42 |
++--------+--------+----------------------+--------------------------------+
+| smoke  | failed | 2021-06-01T13:00:00Z | unavailable                    |
++--------+--------+----------------------+--------------------------------+
+
+`, buf.String())
+
+	r := NewReport(d.experiment)
+	assert.Equal(t, []GateReport{
+		{Gate: "canary", Result: "passed", Time: "2021-06-01T12:00:00Z", PipelineRun: "https://ci.example.com/runs/42"},
+		{Gate: "smoke", Result: "failed", Time: "2021-06-01T13:00:00Z"},
+	}, r.Gates)
+}
+
+func TestRenderNoGateHistory(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	assert.NotContains(t, buf.String(), "Gate History")
+	assert.Empty(t, NewReport(d.experiment).Gates)
+}
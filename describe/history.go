@@ -0,0 +1,191 @@
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"gopkg.in/inf.v0"
+)
+
+// WithHistory populates the Result struct with successive snapshots of one or more experiments, such as those recorded by 'iter8ctl record'.
+// Snapshots of the experiment being described add trends to its metrics in text format.
+func (d *Result) WithHistory(snapshots []expr.Snapshot) *Result {
+	if d.err != nil {
+		return d
+	}
+	d.history = snapshots
+	return d
+}
+
+// experimentHistory returns the snapshots of d's experiment in its history, followed by a snapshot of the experiment itself if its status differs from that of the last snapshot.
+// It returns nil if the experiment has no history.
+func (d *Result) experimentHistory() []expr.Snapshot {
+	var snapshots []expr.Snapshot
+	for _, s := range d.history {
+		if s.Namespace == d.experiment.Namespace && s.Name == d.experiment.Name {
+			snapshots = append(snapshots, s)
+		}
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	last := snapshots[len(snapshots)-1]
+	if current := d.experiment.GetSnapshot(last.Time.Time); !current.SameStatus(last) {
+		snapshots = append(snapshots, current)
+	}
+	return snapshots
+}
+
+// trendStr returns a sparkline of the values followed by an arrow indicating whether the last available value is greater than (↑), less than (↓), or equal to (→) the one before it.
+// It returns the empty string if no value is available, and omits the arrow if fewer than two values are available.
+func trendStr(values []*inf.Dec) string {
+	var available []*inf.Dec
+	for _, v := range values {
+		if v != nil {
+			available = append(available, v)
+		}
+	}
+	if len(available) == 0 {
+		return ""
+	}
+	s := Sparkline(values)
+	if n := len(available); n > 1 {
+		switch available[n-1].Cmp(available[n-2]) {
+		case 1:
+			s += " ↑"
+		case -1:
+			s += " ↓"
+		default:
+			s += " →"
+		}
+	}
+	return s
+}
+
+// RenderHistory writes the history of each experiment in the snapshots to w; that is, when its winning version changed, and how each of its metrics evolved.
+// Experiments are rendered in the order in which they first appear in the snapshots.
+func (d *Result) RenderHistory(w io.Writer) *Result {
+	if d.err != nil {
+		return d
+	}
+	if len(d.history) == 0 {
+		d.err = errors.New("no snapshots in history")
+		return d
+	}
+	var keys []string
+	byKey := map[string][]expr.Snapshot{}
+	for _, s := range d.history {
+		k := s.Namespace + "/" + s.Name
+		if _, ok := byKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		byKey[k] = append(byKey[k], s)
+	}
+	d.description.Reset()
+	for _, k := range keys {
+		d.printHistory(byKey[k])
+	}
+	if d.err == nil {
+		_, d.err = io.WriteString(w, d.description.String()+"\n")
+	}
+	return d
+}
+
+// printHistory prints the winner changes and metric trends of an experiment into d's description buffer.
+func (d *Result) printHistory(snapshots []expr.Snapshot) {
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	d.description.WriteString("\n****** History ******\n")
+	d.description.WriteString("Experiment: " + first.Namespace + "/" + first.Name + "\n")
+	d.description.WriteString(fmt.Sprintf("Snapshots: %d, from %s to %s\n", len(snapshots),
+		first.Time.UTC().Format(time.RFC3339), last.Time.UTC().Format(time.RFC3339)))
+
+	d.description.WriteString("\n****** Winner Changes ******\n")
+	d.description.WriteString("> Snapshots in which the winning version changed.\n")
+	var rows [][]string
+	previous := ""
+	for i, s := range snapshots {
+		winner := (&Result{analysis: s.Experiment().GetAnalysis()}).winnerStr()
+		if i > 0 && winner != previous {
+			rows = append(rows, []string{s.Time.UTC().Format(time.RFC3339), iterationStr(s), previous, d.winnerCell(winner)})
+		}
+		previous = winner
+	}
+	if len(rows) == 0 {
+		d.description.WriteString("Winning version did not change: " + d.winnerCell(previous) + "\n")
+	} else {
+		d.printTable([]string{"Time", "Iteration", "From", "To"}, rows)
+	}
+
+	var metrics []string
+	seen := map[string]bool{}
+	for _, s := range snapshots {
+		if s.AggregatedMetrics == nil {
+			continue
+		}
+		for m := range s.AggregatedMetrics.Data {
+			if !seen[m] {
+				seen[m] = true
+				metrics = append(metrics, m)
+			}
+		}
+	}
+	if len(metrics) == 0 {
+		return
+	}
+	sort.Strings(metrics)
+	d.description.WriteString("\n****** Metric Trends ******\n")
+	d.description.WriteString("> Values of experiment metrics for each version, from the first snapshot to the last.\n")
+	versions := last.Versions
+	rows = nil
+	for _, m := range metrics {
+		for j, version := range versions {
+			values := metricHistory(snapshots, m, version)
+			label := m
+			if j > 0 {
+				label = ""
+			}
+			rows = append(rows, []string{label, version, trendStr(values),
+				d.colorizeValue(formatDec(firstAvailable(values))), d.colorizeValue(formatDec(values[len(values)-1]))})
+		}
+	}
+	d.printTable([]string{"Metric", "Version", "Trend", "First", "Last"}, rows)
+}
+
+// winnerCell returns the winner, colored if it is a version.
+func (d *Result) winnerCell(winner string) string {
+	if winner == "unavailable" || winner == "not found" {
+		return d.colorizeValue(winner)
+	}
+	return d.colorize(winner, colorBold+colorGreen)
+}
+
+// iterationStr returns the number of completed iterations in the snapshot.
+func iterationStr(s expr.Snapshot) string {
+	if s.CompletedIterations == nil {
+		return "0"
+	}
+	return fmt.Sprint(*s.CompletedIterations)
+}
+
+// metricHistory returns the value of a metric for a version in each snapshot; unavailable values are nil.
+func metricHistory(snapshots []expr.Snapshot, metric string, version string) []*inf.Dec {
+	var values []*inf.Dec
+	for _, s := range snapshots {
+		values = append(values, s.Experiment().GetMetricValue(metric, version).Value)
+	}
+	return values
+}
+
+// firstAvailable returns the first value which is not nil, or nil if there is none.
+func firstAvailable(values []*inf.Dec) *inf.Dec {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package describe
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/inf.v0"
+)
+
+// getHistory returns the snapshots in testdata/history.jsonl.
+func getHistory(t *testing.T) []expr.Snapshot {
+	f, err := os.Open(utils.CompletePath("../", "testdata/history.jsonl"))
+	assert.NoError(t, err)
+	defer f.Close()
+	snapshots, err := expr.ReadSnapshots(f)
+	assert.NoError(t, err)
+	return snapshots
+}
+
+func TestRenderHistory(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Builder().WithHistory(getHistory(t)).RenderHistory(&buf).Error())
+	out := buf.String()
+	assert.Contains(t, out, "Experiment: kfserving-test/sklearn-iris-experiment-1\nSnapshots: 4, from 2020-12-28T18:33:34Z to 2020-12-28T18:36:34Z\n")
+	assert.Contains(t, out, "| 2020-12-28T18:34:34Z |         4 | unavailable | not found |")
+	assert.Contains(t, out, "| 2020-12-28T18:35:34Z |         7 | not found   | canary    |")
+	assert.Contains(t, out, "| mean-latency                 | default | █▅▂▁ ↓ | 240.500 | 228.420 |")
+	assert.Contains(t, out, "| error-rate                   | default | ▄▄▄▄ → |   0.000 |   0.000 |")
+}
+
+func TestRenderHistoryUnchangedWinner(t *testing.T) {
+	snapshots := getHistory(t)[2:]
+	var buf bytes.Buffer
+	assert.NoError(t, Builder().WithHistory(snapshots).RenderHistory(&buf).Error())
+	assert.Contains(t, buf.String(), "Winning version did not change: canary\n")
+}
+
+func TestRenderHistoryMultipleExperiments(t *testing.T) {
+	snapshots := getHistory(t)
+	other := snapshots[0]
+	other.Name = "other"
+	snapshots = append([]expr.Snapshot{other}, snapshots...)
+	var buf bytes.Buffer
+	assert.NoError(t, Builder().WithHistory(snapshots).RenderHistory(&buf).Error())
+	out := buf.String()
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("kfserving-test/other")), bytes.Index(buf.Bytes(), []byte("kfserving-test/sklearn-iris-experiment-1")))
+	assert.Contains(t, out, "Snapshots: 1,")
+	assert.Contains(t, out, "Snapshots: 4,")
+}
+
+func TestRenderHistoryEmpty(t *testing.T) {
+	assert.EqualError(t, Builder().RenderHistory(&bytes.Buffer{}).Error(), "no snapshots in history")
+}
+
+func TestRenderMetricTrends(t *testing.T) {
+	var buf bytes.Buffer
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).WithHistory(getHistory(t)).WithSections(SectionMetrics)
+	assert.NoError(t, d.Render(&buf, FormatText).Error())
+	out := buf.String()
+	assert.Contains(t, out, "trend across 4 snapshots")
+	assert.Contains(t, out, "| mean-latency (milliseconds)    | 228.420 █▅▂▁ ↓ | 229.002 █▅▂▁ ↓ |")
+	assert.Contains(t, out, "| error-rate                     | 0.000 ▄▄▄▄ →   | 0.000 ▄▄▄▄ →   |")
+
+	// the experiment is appended to its history if its status differs from the last snapshot
+	buf.Reset()
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment5.yaml")).WithHistory(getHistory(t)).WithSections(SectionMetrics)
+	assert.NoError(t, d.Render(&buf, FormatText).Error())
+	assert.Contains(t, buf.String(), "trend across 5 snapshots")
+	assert.Contains(t, buf.String(), "| request-count                  | 92.223 ████▁ ↓  | 21.387 ████▁ ↓  |")
+
+	// snapshots of other experiments are ignored
+	buf.Reset()
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml")).WithHistory(getHistory(t)).WithSections(SectionMetrics)
+	assert.NoError(t, d.Render(&buf, FormatText).Error())
+	assert.Contains(t, buf.String(), "> Most recently read values of experiment metrics for each version.\n")
+}
+
+func TestTrendStr(t *testing.T) {
+	assert.Equal(t, "", trendStr([]*inf.Dec{nil, nil}))
+	assert.Equal(t, " ▄", trendStr([]*inf.Dec{nil, inf.NewDec(1, 0)}))
+	assert.Equal(t, "█ ▁ ↓", trendStr([]*inf.Dec{inf.NewDec(2, 0), nil, inf.NewDec(1, 0)}))
+	assert.Equal(t, "▁█ ↑", trendStr([]*inf.Dec{inf.NewDec(1, 0), inf.NewDec(2, 0)}))
+}
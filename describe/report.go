@@ -0,0 +1,242 @@
+package describe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"gopkg.in/inf.v0"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// FormatJSON is the report in JSON
+	FormatJSON Format = "json"
+	// FormatYAML is the report in YAML
+	FormatYAML Format = "yaml"
+	// goTemplatePrefix prefixes a Go template evaluated against the report; for example, go-template={{.Winner}}
+	goTemplatePrefix = "go-template="
+	// jsonPathPrefix prefixes a JSONPath expression evaluated against the JSON representation of the report; for example, jsonpath={.winner}
+	jsonPathPrefix = "jsonpath="
+)
+
+// GoTemplate returns the format which evaluates the given Go template (https://golang.org/pkg/text/template/) against the report.
+func GoTemplate(text string) Format {
+	return Format(goTemplatePrefix + text)
+}
+
+// JSONPath returns the format which evaluates the given JSONPath expression (https://kubernetes.io/docs/reference/kubectl/jsonpath/) against the JSON representation of the report.
+func JSONPath(text string) Format {
+	return Format(jsonPathPrefix + text)
+}
+
+// Report is the structured description of an experiment, rendered in JSON, YAML, or using Go templates and JSONPath expressions.
+// Decimal values are not rounded. Unavailable values are absent.
+type Report struct {
+	Name                string            `json:"name"`
+	Namespace           string            `json:"namespace"`
+	Target              string            `json:"target"`
+	TestingPattern      string            `json:"testingPattern"`
+	DeploymentPattern   string            `json:"deploymentPattern"`
+	Stage               string            `json:"stage,omitempty"`
+	CompletedIterations int32             `json:"completedIterations"`
+	Versions            []string          `json:"versions"`
+	WinnerFound         bool              `json:"winnerFound"`
+	Winner              string            `json:"winner,omitempty"`
+	Recommended         string            `json:"recommended,omitempty"`
+	Objectives          []ObjectiveReport `json:"objectives,omitempty"`
+	Rewards             []RewardReport    `json:"rewards,omitempty"`
+	Metrics             []MetricReport    `json:"metrics,omitempty"`
+	Gates               []GateReport      `json:"gates,omitempty"`
+}
+
+// ObjectiveReport is an objective and whether or not each version satisfies it.
+type ObjectiveReport struct {
+	Metric     string      `json:"metric"`
+	UpperLimit json.Number `json:"upperLimit,omitempty"`
+	LowerLimit json.Number `json:"lowerLimit,omitempty"`
+	// Satisfied maps versions to whether or not they satisfy the objective
+	Satisfied map[string]bool `json:"satisfied"`
+}
+
+// RewardReport is a reward, its value for each version, and the versions ranked by these values.
+type RewardReport struct {
+	Metric             string `json:"metric"`
+	PreferredDirection string `json:"preferredDirection"`
+	// Values maps versions to values of the reward metric
+	Values  map[string]json.Number `json:"values"`
+	Best    string                 `json:"best,omitempty"`
+	Ranking []string               `json:"ranking,omitempty"`
+}
+
+// MetricReport is a metric and its value for each version.
+type MetricReport struct {
+	Name  string `json:"name"`
+	Units string `json:"units,omitempty"`
+	// Values maps versions to values of the metric
+	Values map[string]json.Number `json:"values"`
+}
+
+// GateReport is the decision of a CI/CD gate recorded on the experiment using 'iter8ctl annotate'.
+type GateReport struct {
+	Gate        string `json:"gate"`
+	Result      string `json:"result"`
+	Time        string `json:"time"`
+	PipelineRun string `json:"pipelineRun,omitempty"`
+}
+
+// NewReport returns the structured description of the experiment.
+func NewReport(exp *expr.Experiment) *Report {
+	a := exp.GetAnalysis()
+	r := &Report{
+		Name:              exp.Name,
+		Namespace:         exp.Namespace,
+		Target:            exp.Spec.Target,
+		TestingPattern:    string(exp.Spec.Strategy.TestingPattern),
+		DeploymentPattern: string(v2alpha2.DeploymentPatternProgressive),
+		Versions:          a.Versions,
+		WinnerFound:       a.WinnerFound,
+	}
+	if r.Versions == nil {
+		r.Versions = []string{}
+	}
+	if exp.Spec.Strategy.DeploymentPattern != nil {
+		r.DeploymentPattern = string(*exp.Spec.Strategy.DeploymentPattern)
+	}
+	if exp.Status.Stage != nil {
+		r.Stage = string(*exp.Status.Stage)
+	}
+	if exp.Status.CompletedIterations != nil {
+		r.CompletedIterations = *exp.Status.CompletedIterations
+	}
+	if a.Winner != nil {
+		r.Winner = *a.Winner
+	}
+	if a.Recommended != nil {
+		r.Recommended = *a.Recommended
+	}
+
+	for _, oa := range a.Objectives {
+		or := ObjectiveReport{
+			Metric:    oa.Objective.Metric,
+			Satisfied: map[string]bool{},
+		}
+		if oa.Objective.UpperLimit != nil {
+			or.UpperLimit = decNumber(oa.Objective.UpperLimit.AsDec())
+		}
+		if oa.Objective.LowerLimit != nil {
+			or.LowerLimit = decNumber(oa.Objective.LowerLimit.AsDec())
+		}
+		for i, s := range oa.Satisfaction {
+			if s != expr.SatisfactionUnknown {
+				or.Satisfied[a.Versions[i]] = s == expr.Satisfied
+			}
+		}
+		r.Objectives = append(r.Objectives, or)
+	}
+	for _, ra := range a.Rewards {
+		r.Rewards = append(r.Rewards, RewardReport{
+			Metric:             ra.Reward.Metric,
+			PreferredDirection: string(ra.Reward.PreferredDirection),
+			Values:             valuesMap(ra.Values),
+			Best:               ra.Best(),
+			Ranking:            ra.Ranking,
+		})
+	}
+	for _, ma := range a.Metrics {
+		r.Metrics = append(r.Metrics, MetricReport{
+			Name:   ma.Name,
+			Units:  ma.Units,
+			Values: valuesMap(ma.Values),
+		})
+	}
+	for _, g := range exp.GetGateDecisions() {
+		r.Gates = append(r.Gates, GateReport{
+			Gate:        g.Gate,
+			Result:      string(g.Result),
+			Time:        g.Time.UTC().Format(time.RFC3339),
+			PipelineRun: g.PipelineRun,
+		})
+	}
+	return r
+}
+
+// valuesMap maps versions to available values.
+func valuesMap(values []expr.MetricValue) map[string]json.Number {
+	m := map[string]json.Number{}
+	for _, v := range values {
+		if v.Available() {
+			m[v.Version] = decNumber(v.Value)
+		}
+	}
+	return m
+}
+
+// decNumber returns x as a JSON number.
+func decNumber(x *inf.Dec) json.Number {
+	return json.Number(x.String())
+}
+
+// isReportFormat indicates if the format renders the report, rather than text.
+func isReportFormat(format Format) bool {
+	return format == FormatJSON || format == FormatYAML ||
+		strings.HasPrefix(string(format), goTemplatePrefix) || strings.HasPrefix(string(format), jsonPathPrefix)
+}
+
+// renderReport writes the report of the experiment to w in the given format.
+func (d *Result) renderReport(w io.Writer, format Format) *Result {
+	r := NewReport(d.experiment)
+	switch {
+	case format == FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		d.err = enc.Encode(r)
+	case format == FormatYAML:
+		var b []byte
+		if b, d.err = yaml.Marshal(r); d.err == nil {
+			_, d.err = w.Write(b)
+		}
+	case strings.HasPrefix(string(format), goTemplatePrefix):
+		text := strings.TrimPrefix(string(format), goTemplatePrefix)
+		t, err := template.New("report").Parse(text)
+		if err != nil {
+			d.err = expr.NewError(expr.KindUsage, fmt.Sprintf("invalid go-template %q: %v", text, err))
+			return d
+		}
+		d.err = t.Execute(w, r)
+	case strings.HasPrefix(string(format), jsonPathPrefix):
+		d.err = executeJSONPath(w, strings.TrimPrefix(string(format), jsonPathPrefix), r)
+	}
+	return d
+}
+
+// executeJSONPath evaluates the JSONPath expression against the JSON representation of the report, and writes the result to w.
+// As with kubectl, the enclosing braces of the expression are optional.
+func executeJSONPath(w io.Writer, text string, r *Report) error {
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+	jp := jsonpath.New("report").AllowMissingKeys(true)
+	if err := jp.Parse(text); err != nil {
+		return expr.NewError(expr.KindUsage, fmt.Sprintf("invalid jsonpath %q: %v", text, err))
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	// preserve decimal values as they are, rather than as float64
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	return jp.Execute(w, data)
+}
@@ -0,0 +1,70 @@
+package describe
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml"))
+	assert.NoError(t, d.Error())
+	r := NewReport(d.experiment)
+	assert.Equal(t, "istio-quickstart", r.Name)
+	assert.Equal(t, []string{"A", "B"}, r.Versions)
+	assert.Equal(t, "B", r.Winner)
+	assert.Equal(t, map[string]bool{"A": true, "B": true}, r.Objectives[0].Satisfied)
+	assert.Equal(t, json.Number("100"), r.Objectives[0].UpperLimit)
+	assert.Equal(t, "B", r.Rewards[0].Best)
+	assert.Equal(t, json.Number("24.453608192"), r.Rewards[0].Values["B"])
+
+	d.experiment.Status.Analysis = nil
+	r = NewReport(d.experiment)
+	assert.Empty(t, r.Objectives[0].Satisfied)
+	assert.Empty(t, r.Metrics[0].Values)
+	assert.Equal(t, "", r.Winner)
+}
+
+func TestRenderReport(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		format Format
+		out    string
+	}{
+		{format: GoTemplate("{{.Winner}}"), out: "canary"},
+		{format: GoTemplate("{{range .Metrics}}{{.Name}} {{end}}"), out: "95th-percentile-tail-latency mean-latency error-rate request-count "},
+		{format: JSONPath(`{.metrics[?(@.name=="mean-latency")].values.canary}`), out: "229.001070304"},
+		{format: JSONPath(".winner"), out: "canary"},
+		{format: JSONPath(".recommended"), out: "canary"},
+		// missing keys are allowed
+		{format: JSONPath(".metrics[0].values.perfect"), out: ""},
+	} {
+		buf := &bytes.Buffer{}
+		d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, tc.format)
+		assert.NoError(t, d.Error(), tc.format)
+		assert.Equal(t, tc.out, buf.String(), tc.format)
+	}
+
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, FormatJSON)
+	assert.NoError(t, d.Error())
+	r := &Report{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), r))
+	assert.Equal(t, "sklearn-iris-experiment-1", r.Name)
+
+	buf.Reset()
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, FormatYAML)
+	assert.NoError(t, d.Error())
+	assert.Contains(t, buf.String(), "name: sklearn-iris-experiment-1\n")
+
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, GoTemplate("{{.Winner"))
+	assert.Error(t, d.Error())
+	d = Builder().FromFile(utils.CompletePath("../", "testdata/experiment8.yaml")).Render(buf, JSONPath("{.winner"))
+	assert.Error(t, d.Error())
+}
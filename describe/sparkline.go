@@ -0,0 +1,49 @@
+package describe
+
+import (
+	"strings"
+
+	"gopkg.in/inf.v0"
+)
+
+// sparks are the characters of a sparkline, from the lowest value to the highest.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns a sparkline of the given values, with one character per value, scaled between the smallest and largest values.
+// Unavailable (nil) values are rendered as spaces. If all available values are equal, they are rendered at mid-height.
+func Sparkline(values []*inf.Dec) string {
+	var min, max *inf.Dec
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if min == nil || v.Cmp(min) < 0 {
+			min = v
+		}
+		if max == nil || v.Cmp(max) > 0 {
+			max = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if v == nil {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparks[sparkLevel(v, min, max)])
+	}
+	return b.String()
+}
+
+// sparkLevel returns the index in sparks of v, which lies between min and max.
+func sparkLevel(v, min, max *inf.Dec) int {
+	span := new(inf.Dec).Sub(max, min)
+	if span.Sign() == 0 {
+		return len(sparks)/2 - 1
+	}
+	// (v - min) * (len(sparks) - 1) / span, rounded to the nearest level
+	num := new(inf.Dec).Sub(v, min)
+	num.Mul(num, inf.NewDec(int64(len(sparks)-1), 0))
+	level := new(inf.Dec).QuoRound(num, span, 0, inf.RoundHalfUp)
+	return int(level.UnscaledBig().Int64())
+}
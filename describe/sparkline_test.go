@@ -0,0 +1,25 @@
+package describe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/inf.v0"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▅█", Sparkline([]*inf.Dec{inf.NewDec(0, 0), inf.NewDec(5, 1), inf.NewDec(1, 0)}))
+	assert.Equal(t, "▄ ▄", Sparkline([]*inf.Dec{inf.NewDec(3, 0), nil, inf.NewDec(3, 0)}))
+	assert.Equal(t, "  ", Sparkline([]*inf.Dec{nil, nil}))
+}
+
+func ExampleSparkline() {
+	values := []*inf.Dec{}
+	for _, v := range []int64{1, 2, 3, 4, 5, 6, 7, 8} {
+		values = append(values, inf.NewDec(v, 0))
+	}
+	fmt.Println(Sparkline(values))
+	// Output: ▁▂▃▄▅▆▇█
+}
@@ -0,0 +1,149 @@
+package describe
+
+import (
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxVersionColumns is the maximum number of versions rendered as columns of a matrix; matrices with more versions are transposed, so that versions are rendered as rows.
+const maxVersionColumns = 4
+
+// minColWidth is the width below which cells are not wrapped to fit the terminal.
+const minColWidth = 10
+
+// ANSI escape sequences used to color cells.
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorFaint = "\033[2m"
+)
+
+// WithWidth wraps tables to fit within the given number of columns, such as the width of the terminal.
+// Tables are wrapped at a fixed cell width if width is not positive.
+func (d *Result) WithWidth(width int) *Result {
+	if d.err != nil {
+		return d
+	}
+	d.width = width
+	return d
+}
+
+// WithColor enables or disables coloring of satisfied and unsatisfied objectives, best reward values, and the winning version.
+func (d *Result) WithColor(color bool) *Result {
+	if d.err != nil {
+		return d
+	}
+	d.color = color
+	return d
+}
+
+// colorize returns s wrapped in the given ANSI color if coloring is enabled.
+func (d *Result) colorize(s string, color string) string {
+	if !d.color || s == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// colorizeValue colors "unavailable" cells faint, and leaves other cells as they are.
+func (d *Result) colorizeValue(s string) string {
+	if s == "unavailable" {
+		return d.colorize(s, colorFaint)
+	}
+	return s
+}
+
+// printMatrix prints a matrix with one row per label and one column per version into d's description buffer; cells[i][j] is the entry for label i and version j.
+// If there are more than maxVersionColumns versions, the matrix is transposed, so that versions are rendered as rows.
+func (d *Result) printMatrix(corner string, labels []string, cells [][]string) {
+	versions := d.analysis.Versions
+	header := append([]string{corner}, versions...)
+	rows := make([][]string, len(labels))
+	for i, label := range labels {
+		rows[i] = append([]string{label}, cells[i]...)
+	}
+	if len(versions) > maxVersionColumns {
+		header = append([]string{"Version"}, labels...)
+		rows = make([][]string, len(versions))
+		for j, version := range versions {
+			rows[j] = []string{version}
+			for i := range labels {
+				rows[j] = append(rows[j], cells[i][j])
+			}
+		}
+	}
+
+	d.printTable(header, rows)
+}
+
+// printTable prints a table with the given header and rows into d's description buffer, wrapping cells to fit within d's width.
+func (d *Result) printTable(header []string, rows [][]string) {
+	colWidth := tablewriter.MAX_ROW_WIDTH
+	if d.width > 0 {
+		colWidth = fitColWidth(header, rows, d.width)
+	}
+	table := tablewriter.NewWriter(&d.description)
+	table.SetRowLine(true)
+	table.SetColWidth(colWidth)
+	if d.color {
+		// tablewriter counts escape sequences towards the width of text while wrapping; wrap uncolored cells in advance instead
+		table.SetAutoWrapText(false)
+		wrapCells(header, colWidth)
+		for _, row := range rows {
+			wrapCells(row, colWidth)
+		}
+	}
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// wrapCells wraps cells without escape sequences at the given width.
+func wrapCells(cells []string, width int) {
+	for i, cell := range cells {
+		if !strings.Contains(cell, "\033[") && tablewriter.DisplayWidth(cell) > width {
+			lines, _ := tablewriter.WrapString(cell, width)
+			cells[i] = strings.Join(lines, "\n")
+		}
+	}
+}
+
+// fitColWidth returns the largest cell width at which the table fits within width columns, and no less than minColWidth.
+func fitColWidth(header []string, rows [][]string, width int) int {
+	natural := make([]int, len(header))
+	for i, h := range header {
+		natural[i] = tablewriter.DisplayWidth(h)
+	}
+	max := 0
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(natural) && tablewriter.DisplayWidth(cell) > natural[i] {
+				natural[i] = tablewriter.DisplayWidth(cell)
+			}
+		}
+	}
+	for _, w := range natural {
+		if w > max {
+			max = w
+		}
+	}
+	// each column is padded by a space on either side, and followed by a separator
+	overhead := 3*len(natural) + 1
+	for colWidth := max; colWidth > minColWidth; colWidth-- {
+		total := overhead
+		for _, w := range natural {
+			if w < colWidth {
+				total += w
+			} else {
+				total += colWidth
+			}
+		}
+		if total <= width {
+			return colWidth
+		}
+	}
+	return minColWidth
+}
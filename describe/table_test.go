@@ -0,0 +1,70 @@
+package describe
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestFitColWidth(t *testing.T) {
+	header := []string{"Objective", "A", "B"}
+	rows := [][]string{{"iter8-istio/mean-latency <= 100.000", "true", "true"}}
+	// the table fits without wrapping
+	assert.Equal(t, 35, fitColWidth(header, rows, 120))
+	// the objective is wrapped to fit: 3 * 3 + 1 + 4 + 4 + 20 = 38
+	assert.Equal(t, 20, fitColWidth(header, rows, 38))
+	// cells are never narrower than minColWidth
+	assert.Equal(t, minColWidth, fitColWidth(header, rows, 5))
+}
+
+func TestRenderWidth(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml")).WithSections(SectionObjectives).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	// by default, cells are wrapped at a fixed width
+	assert.NotContains(t, buf.String(), "iter8-istio/mean-latency <= 100.000")
+
+	buf.Reset()
+	d.WithWidth(120).Render(buf, FormatText)
+	assert.Contains(t, buf.String(), "iter8-istio/mean-latency <= 100.000")
+}
+
+func TestRenderTransposed(t *testing.T) {
+	t.Parallel()
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml"))
+	assert.NoError(t, d.Error())
+	exp := d.experiment
+	for i := 0; i < maxVersionColumns; i++ {
+		exp.Spec.VersionInfo.Candidates = append(exp.Spec.VersionInfo.Candidates, v2alpha2.VersionDetail{Name: fmt.Sprintf("C%v", i)})
+	}
+	buf := &bytes.Buffer{}
+	d = Builder().WithExperiment(exp).WithSections(SectionObjectives).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	lines := strings.Split(buf.String(), "\n")
+	// one row per version, along with the header and borders
+	assert.Contains(t, buf.String(), "| VERSION |")
+	assert.Contains(t, buf.String(), "| C3      | unavailable")
+	assert.Greater(t, len(lines), 2*(maxVersionColumns+2))
+}
+
+func TestRenderColor(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	d := Builder().FromFile(utils.CompletePath("../", "testdata/experiment12.yaml")).Render(buf, FormatText)
+	assert.NoError(t, d.Error())
+	assert.NotContains(t, buf.String(), "\033[")
+
+	buf.Reset()
+	d.WithColor(true).Render(buf, FormatText)
+	assert.Contains(t, buf.String(), colorGreen+"true"+colorReset)
+	assert.Contains(t, buf.String(), colorBold+colorGreen+"24.454 *"+colorReset)
+	assert.Contains(t, buf.String(), "Winning version: "+colorBold+colorGreen+"B"+colorReset)
+}
@@ -61,6 +61,18 @@
 //  | request-count                  | 117.444444445 |  57.714400001 |
 //  +--------------------------------+---------------+---------------+
 //
+// Exit codes
+//
+// `iter8ctl` exits with one of the following codes, so that scripts and CI/CD pipelines can react to the outcome of a command.
+//  0  success
+//  1  one or more asserted conditions are not satisfied
+//  2  usage error; for example, an unknown flag or a malformed assertion
+//  3  experiment not found
+//  4  K8s cluster could not be reached, or the request was not authorized
+//  5  request to the K8s cluster timed out
+//  6  one or more asserted conditions are not satisfied, and the experiment has failed
+//  7  runtime error; for example, a file could not be read, or a webhook could not be notified
+//
 // Removal
 //
 // Remove `iter8ctl` as follows.
@@ -0,0 +1,221 @@
+package experiment
+
+import (
+	"sort"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"gopkg.in/inf.v0"
+)
+
+// Satisfaction indicates whether or not a version satisfies an objective.
+// Its zero value, SatisfactionUnknown, indicates the assessment is unavailable.
+type Satisfaction int
+
+const (
+	// SatisfactionUnknown implies the objective has not been assessed for the version
+	SatisfactionUnknown Satisfaction = iota
+	// Satisfied implies the version satisfies the objective
+	Satisfied
+	// NotSatisfied implies the version does not satisfy the objective
+	NotSatisfied
+)
+
+// String returns "true", "false", or "unavailable".
+func (s Satisfaction) String() string {
+	switch s {
+	case Satisfied:
+		return "true"
+	case NotSatisfied:
+		return "false"
+	default:
+		return "unavailable"
+	}
+}
+
+// MetricValue is the most recently observed value of a metric for a version.
+// Value, Min, Max, and SampleSize are nil if unavailable. Values are not rounded.
+type MetricValue struct {
+	Version    string
+	Value      *inf.Dec
+	Min        *inf.Dec
+	Max        *inf.Dec
+	SampleSize *int32
+}
+
+// Available indicates if the value of the metric is available.
+func (v MetricValue) Available() bool {
+	return v.Value != nil
+}
+
+// MetricAnalysis contains the values of a metric for every version.
+type MetricAnalysis struct {
+	// Name of the metric, as in the experiment's status.metrics section
+	Name string
+	// Units of the metric; empty if unspecified
+	Units string
+	// Min and Max are the extreme values of the metric across all versions; nil if unavailable
+	Min *inf.Dec
+	Max *inf.Dec
+	// Values contains one value per version, in the order of Analysis.Versions
+	Values []MetricValue
+}
+
+// ObjectiveAnalysis contains the satisfaction of an objective by every version.
+type ObjectiveAnalysis struct {
+	Objective v2alpha2.Objective
+	// Satisfaction contains one entry per version, in the order of Analysis.Versions
+	Satisfaction []Satisfaction
+}
+
+// RewardAnalysis contains the values of a reward metric for every version, and the versions ranked by these values.
+type RewardAnalysis struct {
+	Reward v2alpha2.Reward
+	// Values contains one value per version, in the order of Analysis.Versions
+	Values []MetricValue
+	// Ranking contains versions whose value is available, from best to worst; ties preserve the order of Analysis.Versions
+	Ranking []string
+}
+
+// Best returns the best version with respect to the reward, or the empty string if no value is available.
+func (r RewardAnalysis) Best() string {
+	if len(r.Ranking) == 0 {
+		return ""
+	}
+	return r.Ranking[0]
+}
+
+// Analysis is a typed view of the analysis of an experiment, meant for programs rather than humans.
+// Unavailable values are nil, empty, or SatisfactionUnknown, rather than the string "unavailable".
+type Analysis struct {
+	// Versions contains the baseline followed by candidates
+	Versions []string
+	// WinnerAssessed is true if the experiment includes a winner assessment
+	WinnerAssessed bool
+	// WinnerFound is true if the experiment has found a winner
+	WinnerFound bool
+	// Winner is the winning version; nil if no winner is found
+	Winner *string
+	// Recommended is the version recommended for promotion; nil if unavailable
+	Recommended *string
+	// Objectives are in the order of the experiment's spec.criteria.objectives section
+	Objectives []ObjectiveAnalysis
+	// Rewards are in the order of the experiment's spec.criteria.rewards section
+	Rewards []RewardAnalysis
+	// Metrics are in the order of the experiment's status.metrics section
+	Metrics []MetricAnalysis
+}
+
+// GetAnalysis returns the typed analysis of the experiment.
+func (e *Experiment) GetAnalysis() *Analysis {
+	a := &Analysis{
+		Versions:    e.GetVersions(),
+		WinnerFound: e.WinnerFound(),
+		Recommended: e.Status.VersionRecommendedForPromotion,
+	}
+	if ana := e.Status.Analysis; ana != nil && ana.WinnerAssessment != nil {
+		a.WinnerAssessed = true
+		if a.WinnerFound {
+			a.Winner = ana.WinnerAssessment.Data.Winner
+		}
+	}
+	if e.Spec.Criteria != nil {
+		for i, objective := range e.Spec.Criteria.Objectives {
+			oa := ObjectiveAnalysis{Objective: objective}
+			for _, version := range a.Versions {
+				oa.Satisfaction = append(oa.Satisfaction, e.GetSatisfaction(i, version))
+			}
+			a.Objectives = append(a.Objectives, oa)
+		}
+		for _, reward := range e.Spec.Criteria.Rewards {
+			a.Rewards = append(a.Rewards, e.GetRewardAnalysis(reward))
+		}
+	}
+	for _, mi := range e.Status.Metrics {
+		a.Metrics = append(a.Metrics, e.GetMetricAnalysis(mi))
+	}
+	return a
+}
+
+// GetMetricValue returns the value of a metric for a version.
+func (e *Experiment) GetMetricValue(metric string, version string) MetricValue {
+	v := MetricValue{Version: version}
+	if e.Status.Analysis == nil || e.Status.Analysis.AggregatedMetrics == nil {
+		return v
+	}
+	if vals, ok := e.Status.Analysis.AggregatedMetrics.Data[metric]; ok {
+		if val, ok := vals.Data[version]; ok {
+			if val.Value != nil {
+				v.Value = val.Value.AsDec()
+			}
+			if val.Min != nil {
+				v.Min = val.Min.AsDec()
+			}
+			if val.Max != nil {
+				v.Max = val.Max.AsDec()
+			}
+			v.SampleSize = val.SampleSize
+		}
+	}
+	return v
+}
+
+// GetMetricAnalysis returns the values of the given metric for every version.
+func (e *Experiment) GetMetricAnalysis(metricInfo v2alpha2.MetricInfo) MetricAnalysis {
+	ma := MetricAnalysis{Name: metricInfo.Name}
+	if metricInfo.MetricObj.Spec.Units != nil {
+		ma.Units = *metricInfo.MetricObj.Spec.Units
+	}
+	if a := e.Status.Analysis; a != nil && a.AggregatedMetrics != nil {
+		if vals, ok := a.AggregatedMetrics.Data[metricInfo.Name]; ok {
+			if vals.Min != nil {
+				ma.Min = vals.Min.AsDec()
+			}
+			if vals.Max != nil {
+				ma.Max = vals.Max.AsDec()
+			}
+		}
+	}
+	for _, version := range e.GetVersions() {
+		ma.Values = append(ma.Values, e.GetMetricValue(metricInfo.Name, version))
+	}
+	return ma
+}
+
+// GetSatisfaction returns whether or not a version satisfies the objective with the given index in spec.criteria.objectives.
+func (e *Experiment) GetSatisfaction(objectiveIndex int, version string) Satisfaction {
+	if e.Status.Analysis == nil || e.Status.Analysis.VersionAssessments == nil {
+		return SatisfactionUnknown
+	}
+	if vals, ok := e.Status.Analysis.VersionAssessments.Data[version]; ok {
+		if objectiveIndex >= 0 && len(vals) > objectiveIndex {
+			if vals[objectiveIndex] {
+				return Satisfied
+			}
+			return NotSatisfied
+		}
+	}
+	return SatisfactionUnknown
+}
+
+// GetRewardAnalysis returns the values of the reward metric for every version, and the versions ranked by these values.
+func (e *Experiment) GetRewardAnalysis(reward v2alpha2.Reward) RewardAnalysis {
+	ra := RewardAnalysis{Reward: reward}
+	var available []MetricValue
+	for _, version := range e.GetVersions() {
+		v := e.GetMetricValue(reward.Metric, version)
+		ra.Values = append(ra.Values, v)
+		if v.Available() {
+			available = append(available, v)
+		}
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		if reward.PreferredDirection == v2alpha2.PreferredDirectionHigher {
+			return available[i].Value.Cmp(available[j].Value) > 0
+		}
+		return available[i].Value.Cmp(available[j].Value) < 0
+	})
+	for _, v := range available {
+		ra.Ranking = append(ra.Ranking, v.Version)
+	}
+	return ra
+}
@@ -0,0 +1,89 @@
+package experiment
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/inf.v0"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+/* Tests */
+
+func TestGetAnalysis(t *testing.T) {
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	a := exp.GetAnalysis()
+	assert.Equal(t, []string{"A", "B"}, a.Versions)
+
+	assert.Len(t, a.Objectives, 2)
+	assert.Equal(t, "iter8-istio/mean-latency", a.Objectives[0].Objective.Metric)
+	assert.Equal(t, []Satisfaction{Satisfied, Satisfied}, a.Objectives[0].Satisfaction)
+
+	assert.Len(t, a.Rewards, 1)
+	assert.Equal(t, []string{"B", "A"}, a.Rewards[0].Ranking)
+	assert.Equal(t, "B", a.Rewards[0].Best())
+	assert.Equal(t, "5.029875003", a.Rewards[0].Values[0].Value.String())
+
+	assert.Len(t, a.Metrics, len(exp.Status.Metrics))
+	for _, ma := range a.Metrics {
+		assert.Len(t, ma.Values, 2)
+	}
+}
+
+func TestGetAnalysisUnavailable(t *testing.T) {
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	exp.Status.Analysis = nil
+	a := exp.GetAnalysis()
+	assert.False(t, a.WinnerAssessed)
+	assert.False(t, a.WinnerFound)
+	assert.Nil(t, a.Winner)
+	assert.Equal(t, []Satisfaction{SatisfactionUnknown, SatisfactionUnknown}, a.Objectives[1].Satisfaction)
+	assert.Empty(t, a.Rewards[0].Ranking)
+	assert.Equal(t, "", a.Rewards[0].Best())
+	for _, ma := range a.Metrics {
+		for _, v := range ma.Values {
+			assert.False(t, v.Available())
+		}
+	}
+}
+
+func TestGetMetricValue(t *testing.T) {
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	min, max, ss := resource.MustParse("1"), resource.MustParse("30"), int32(100)
+	d := exp.Status.Analysis.AggregatedMetrics.Data["books-purchased"]
+	vd := d.Data["B"]
+	vd.Min, vd.Max, vd.SampleSize = &min, &max, &ss
+	d.Data["B"] = vd
+	d.Min, d.Max = &min, &max
+	exp.Status.Analysis.AggregatedMetrics.Data["books-purchased"] = d
+
+	v := exp.GetMetricValue("books-purchased", "B")
+	assert.True(t, v.Available())
+	assert.Equal(t, "24.453608192", v.Value.String())
+	assert.Equal(t, 0, v.Min.Cmp(inf.NewDec(1, 0)))
+	assert.Equal(t, 0, v.Max.Cmp(inf.NewDec(30, 0)))
+	assert.Equal(t, int32(100), *v.SampleSize)
+
+	assert.False(t, exp.GetMetricValue("books-purchased", "C").Available())
+	assert.False(t, exp.GetMetricValue("fake", "B").Available())
+}
+
+func TestGetRewardAnalysis(t *testing.T) {
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	ra := exp.GetRewardAnalysis(v2alpha2.Reward{Metric: "iter8-istio/mean-latency", PreferredDirection: v2alpha2.PreferredDirectionLower})
+	assert.Equal(t, []string{"B", "A"}, ra.Ranking)
+	// ties preserve version order
+	ra = exp.GetRewardAnalysis(v2alpha2.Reward{Metric: "iter8-istio/error-rate", PreferredDirection: v2alpha2.PreferredDirectionHigher})
+	assert.Equal(t, []string{"A", "B"}, ra.Ranking)
+}
+
+func ExampleSatisfaction_String() {
+	fmt.Println(Satisfied, NotSatisfied, SatisfactionUnknown)
+	// output: true false unavailable
+}
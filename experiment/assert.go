@@ -0,0 +1,275 @@
+package experiment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity determines whether an unsatisfied condition fails an assertion.
+type Severity string
+
+const (
+	// SeverityError implies an unsatisfied condition fails the assertion; this is the default
+	SeverityError Severity = "error"
+	// SeverityWarn implies an unsatisfied condition is reported as a warning without failing the assertion
+	SeverityWarn Severity = "warn"
+)
+
+// ConditionResult is the outcome of asserting a single condition.
+type ConditionResult struct {
+	// Name identifies the asserted condition; for metric assertions, it is the assertion expression
+	Name string
+	// Satisfied indicates if the condition holds for the experiment
+	Satisfied bool
+	// Reason explains why the condition is not satisfied, or why it is skipped; it is empty for satisfied conditions
+	Reason string
+	// Severity of the condition; an empty severity is treated as SeverityError
+	Severity Severity
+	// Skipped indicates the condition was not evaluated because it does not apply to the experiment
+	Skipped bool
+}
+
+// Failed indicates if the condition causes the assertion to fail.
+func (res ConditionResult) Failed() bool {
+	return !res.Satisfied && !res.Skipped && res.Severity != SeverityWarn
+}
+
+// Warned indicates if the condition is reported as a warning.
+func (res ConditionResult) Warned() bool {
+	return !res.Satisfied && !res.Skipped && res.Severity == SeverityWarn
+}
+
+// AssertResult is the outcome of asserting a set of conditions.
+// It contains one entry per asserted condition, in the order in which the conditions were asserted; for example, `iter8ctl assert` asserts conditions grouped by kind, rather than in the order of its flags.
+type AssertResult struct {
+	Results []ConditionResult
+}
+
+// Satisfied indicates if every asserted condition is satisfied, ignoring warnings and skipped conditions.
+func (r *AssertResult) Satisfied() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the results of conditions that fail the assertion.
+func (r *AssertResult) Failures() []ConditionResult {
+	var failures []ConditionResult
+	for _, res := range r.Results {
+		if res.Failed() {
+			failures = append(failures, res)
+		}
+	}
+	return failures
+}
+
+// Warnings returns the results of unsatisfied conditions whose severity is SeverityWarn.
+func (r *AssertResult) Warnings() []ConditionResult {
+	var warnings []ConditionResult
+	for _, res := range r.Results {
+		if res.Warned() {
+			warnings = append(warnings, res)
+		}
+	}
+	return warnings
+}
+
+// Err returns an error of kind KindAssertionFailed listing the reasons for every failed condition, or nil if the assertion is satisfied.
+func (r *AssertResult) Err() error {
+	failures := r.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+	reasons := make([]string, len(failures))
+	for i, f := range failures {
+		reasons[i] = f.Reason
+	}
+	return NewError(KindAssertionFailed, strings.Join(reasons, "; "))
+}
+
+// Summary returns a single line summary of the outcome of the assertion, naming every failed condition along with its reason.
+func (r *AssertResult) Summary() string {
+	failures := r.Failures()
+	if len(failures) == 0 {
+		return fmt.Sprintf("all %v conditions satisfied", len(r.Results))
+	}
+	reasons := make([]string, len(failures))
+	for i, f := range failures {
+		reasons[i] = fmt.Sprintf("%s (%s)", f.Name, f.Reason)
+	}
+	return fmt.Sprintf("%v of %v conditions not satisfied: %s", len(failures), len(r.Results), strings.Join(reasons, ", "))
+}
+
+// Checklist returns a human readable checklist with one line per asserted condition, followed by a summary line.
+func (r *AssertResult) Checklist() string {
+	b := strings.Builder{}
+	for _, res := range r.Results {
+		switch {
+		case res.Skipped:
+			b.WriteString(fmt.Sprintf("[SKIP] %s: %s\n", res.Name, res.Reason))
+		case res.Satisfied:
+			b.WriteString(fmt.Sprintf("[PASS] %s\n", res.Name))
+		case res.Warned():
+			b.WriteString(fmt.Sprintf("[WARN] %s: %s\n", res.Name, res.Reason))
+		default:
+			b.WriteString(fmt.Sprintf("[FAIL] %s: %s\n", res.Name, res.Reason))
+		}
+	}
+	if failures := r.Failures(); len(failures) > 0 {
+		b.WriteString(fmt.Sprintf("%v of %v conditions not satisfied.\n", len(failures), len(r.Results)))
+	} else {
+		b.WriteString("All conditions satisfied.\n")
+	}
+	if warnings := r.Warnings(); len(warnings) > 0 {
+		b.WriteString(fmt.Sprintf("%v warnings.\n", len(warnings)))
+	}
+	return b.String()
+}
+
+// ParseConditionType returns the ConditionType named by s.
+func ParseConditionType(s string) (ConditionType, error) {
+	switch s {
+	case string(Completed):
+		return Completed, nil
+	case string(WinnerFound):
+		return WinnerFound, nil
+	default:
+		return "", errors.New("Invalid condition: " + s)
+	}
+}
+
+// Assert verifies a given set of conditions for the experiment.
+// Every condition is evaluated; the returned result records whether each of them is satisfied.
+func (e *Experiment) Assert(conditions []ConditionType) *AssertResult {
+	r := &AssertResult{}
+	for _, cond := range conditions {
+		r.Results = append(r.Results, e.assertCondition(cond))
+	}
+	return r
+}
+
+// assertCondition verifies a single condition for the experiment.
+func (e *Experiment) assertCondition(cond ConditionType) ConditionResult {
+	res := ConditionResult{Name: string(cond), Satisfied: true}
+	switch cond {
+	case Completed:
+		if !e.Completed() {
+			res.Satisfied, res.Reason = false, "experiment has not completed"
+		}
+	case WinnerFound:
+		if !e.WinnerFound() {
+			res.Satisfied, res.Reason = false, "no winner found in experiment"
+		}
+	default:
+		res.Satisfied, res.Reason = false, "unsupported condition found in assertion"
+	}
+	return res
+}
+
+// Version roles which can be used in place of version names in AssertWinner and AssertRecommended.
+const (
+	// BaselineRole refers to the baseline version of the experiment
+	BaselineRole = "baseline"
+	// CandidateRole refers to any candidate version of the experiment
+	CandidateRole = "candidate"
+)
+
+// ResolveVersions returns the names of versions referred to by the given version name or role.
+// A version name takes precedence over a role of the same name. An error is returned if nothing matches.
+func (e *Experiment) ResolveVersions(versionOrRole string) ([]string, error) {
+	versions := e.GetVersions()
+	if containsString(versions, versionOrRole) {
+		return []string{versionOrRole}, nil
+	}
+	if e.Spec.VersionInfo != nil {
+		switch versionOrRole {
+		case BaselineRole:
+			return []string{e.Spec.VersionInfo.Baseline.Name}, nil
+		case CandidateRole:
+			if len(versions) > 1 {
+				return versions[1:], nil
+			}
+			return nil, errors.New("experiment has no candidate versions")
+		}
+	}
+	return nil, fmt.Errorf("unknown version %s; versions in experiment: [%s]", versionOrRole, strings.Join(versions, ", "))
+}
+
+// assertVersionIs verifies that actual is one of the versions referred to by versionOrRole.
+// what describes actual in the reason, for example "winning version".
+func (e *Experiment) assertVersionIs(res ConditionResult, what string, actual *string, versionOrRole string) ConditionResult {
+	expected, err := e.ResolveVersions(versionOrRole)
+	if err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+	if actual == nil || !containsString(expected, *actual) {
+		res.Reason = fmt.Sprintf("%s is %s, not %s", what, stringOr(actual, "unknown"), versionOrRole)
+		if len(expected) > 1 || expected[0] != versionOrRole {
+			res.Reason += fmt.Sprintf(" [%s]", strings.Join(expected, ", "))
+		}
+		return res
+	}
+	res.Satisfied = true
+	return res
+}
+
+// AssertWinner verifies that the experiment found a winner and that the winner is the given version.
+// The version may also be specified by its role; i.e., baseline or candidate.
+func (e *Experiment) AssertWinner(versionOrRole string) ConditionResult {
+	res := ConditionResult{Name: "winner is " + versionOrRole}
+	if !e.WinnerFound() {
+		res.Reason = "no winner found in experiment"
+		return res
+	}
+	return e.assertVersionIs(res, "winning version", e.Status.Analysis.WinnerAssessment.Data.Winner, versionOrRole)
+}
+
+// AssertRecommended verifies that the given version is recommended for promotion.
+// The version may also be specified by its role; i.e., baseline or candidate.
+func (e *Experiment) AssertRecommended(versionOrRole string) ConditionResult {
+	res := ConditionResult{Name: "recommended version is " + versionOrRole}
+	if e.Status.VersionRecommendedForPromotion == nil {
+		res.Reason = "no version recommended for promotion in experiment"
+		return res
+	}
+	return e.assertVersionIs(res, "version recommended for promotion", e.Status.VersionRecommendedForPromotion, versionOrRole)
+}
+
+// AssertMinIterations verifies that the experiment completed at least n iterations.
+func (e *Experiment) AssertMinIterations(n int32) ConditionResult {
+	res := ConditionResult{Name: fmt.Sprintf("at least %v completed iterations", n)}
+	var c int32
+	if e.Status.CompletedIterations != nil {
+		c = *e.Status.CompletedIterations
+	}
+	if c < n {
+		res.Reason = fmt.Sprintf("experiment completed %v iterations, fewer than %v", c, n)
+		return res
+	}
+	res.Satisfied = true
+	return res
+}
+
+// AssertMaxAge verifies that no more than maxAge has elapsed at time now since the experiment was created.
+func (e *Experiment) AssertMaxAge(maxAge time.Duration, now time.Time) ConditionResult {
+	res := ConditionResult{Name: fmt.Sprintf("created within %v", maxAge)}
+	if e.CreationTimestamp.IsZero() {
+		res.Reason = "experiment creation timestamp is unavailable"
+		return res
+	}
+	if age := now.Sub(e.CreationTimestamp.Time); age > maxAge {
+		res.Reason = fmt.Sprintf("experiment was created %v ago, more than %v", age.Round(time.Second), maxAge)
+		return res
+	}
+	res.Satisfied = true
+	return res
+}
+
+// stringOr returns the string pointed to by s, or def if s is nil.
+func stringOr(s *string, def string) string {
+	if s == nil {
+		return def
+	}
+	return *s
+}
@@ -0,0 +1,234 @@
+package experiment
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/handler/tasks"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* Tests */
+
+func TestAssertComplete(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").WithCondition(
+		v2alpha2.ExperimentConditionExperimentCompleted,
+		corev1.ConditionTrue,
+		"experiment is over",
+		"",
+	).Build()
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{Completed}).Err()
+
+	assert.NoError(t, err)
+}
+
+func TestAssertInComplete(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").WithCondition(
+		v2alpha2.ExperimentConditionExperimentCompleted,
+		corev1.ConditionFalse,
+		"experiment is not over",
+		"",
+	).Build()
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{Completed}).Err()
+
+	assert.Error(t, err)
+}
+
+func TestAssertWinnerFound(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").Build()
+	exp.Status.Analysis = &v2alpha2.Analysis{}
+	exp.Status.Analysis.WinnerAssessment = &v2alpha2.WinnerAssessmentAnalysis{
+		Data: v2alpha2.WinnerAssessmentData{
+			WinnerFound: true,
+			Winner:      tasks.StringPointer("the best"),
+		},
+	}
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{WinnerFound}).Err()
+
+	assert.NoError(t, err)
+}
+
+func TestAssertNoWinnerFound(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").Build()
+	exp.Status.Analysis = &v2alpha2.Analysis{}
+	exp.Status.Analysis.WinnerAssessment = &v2alpha2.WinnerAssessmentAnalysis{
+		AnalysisMetaData: v2alpha2.AnalysisMetaData{},
+		Data: v2alpha2.WinnerAssessmentData{
+			WinnerFound: false,
+		},
+	}
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{WinnerFound}).Err()
+
+	assert.Error(t, err)
+}
+
+func TestAssertNoWinnerFound2(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").Build()
+	exp.Status.Analysis = &v2alpha2.Analysis{}
+	exp.Status.Analysis.WinnerAssessment = nil
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{WinnerFound}).Err()
+
+	assert.Error(t, err)
+}
+
+func TestAssertNoWinnerFound3(t *testing.T) {
+	exp := v2alpha2.NewExperiment("test", "test").Build()
+	exp.Status.Analysis = nil
+
+	err := (&Experiment{
+		*exp,
+	}).Assert([]ConditionType{WinnerFound}).Err()
+
+	assert.Error(t, err)
+}
+
+func TestAssertReportsAllFailures(t *testing.T) {
+	exp, err := getExp("experiment1")
+	assert.NoError(t, err)
+
+	res := exp.Assert([]ConditionType{Completed, WinnerFound, ConditionType("fake")})
+	assert.False(t, res.Satisfied())
+	assert.Equal(t, 3, len(res.Results))
+	assert.Equal(t, 3, len(res.Failures()))
+	assert.Equal(t, string(WinnerFound), res.Results[1].Name)
+	assert.EqualError(t, res.Err(), "experiment has not completed; no winner found in experiment; unsupported condition found in assertion")
+}
+
+func TestAssertMixedResults(t *testing.T) {
+	exp, err := getExp("experiment9")
+	assert.NoError(t, err)
+
+	res := exp.Assert([]ConditionType{Completed, WinnerFound})
+	assert.False(t, res.Satisfied())
+	assert.True(t, res.Results[0].Satisfied)
+	assert.Empty(t, res.Results[0].Reason)
+	assert.False(t, res.Results[1].Satisfied)
+	assert.Equal(t, []ConditionResult{res.Results[1]}, res.Failures())
+}
+
+func ExampleAssertResult_Checklist() {
+	// Read in an experiment object from the testdata folder
+	filePath := utils.CompletePath("../testdata", "experiment9.yaml")
+	buf, _ := ioutil.ReadFile(filePath)
+	exp := &Experiment{}
+	yaml.Unmarshal(buf, exp)
+	// Assert that the experiment has completed and found a winner
+	fmt.Print(exp.Assert([]ConditionType{Completed, WinnerFound}).Checklist())
+	// output:
+	// [PASS] completed
+	// [FAIL] winnerFound: no winner found in experiment
+	// 1 of 2 conditions not satisfied.
+}
+
+func TestAssertWinner(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertWinner("canary").Satisfied)
+	assert.Equal(t, "winning version is canary, not default", exp.AssertWinner("default").Reason)
+
+	assert.True(t, exp.AssertWinner(CandidateRole).Satisfied)
+	assert.Equal(t, "winning version is canary, not baseline [default]", exp.AssertWinner(BaselineRole).Reason)
+	assert.Equal(t, "unknown version perfect; versions in experiment: [default, canary]", exp.AssertWinner("perfect").Reason)
+
+	exp, err = getExp("experiment9")
+	assert.NoError(t, err)
+	assert.Equal(t, "no winner found in experiment", exp.AssertWinner("canary").Reason)
+}
+
+func TestAssertRecommended(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertRecommended("canary").Satisfied)
+	assert.True(t, exp.AssertRecommended(CandidateRole).Satisfied)
+	assert.Equal(t, "recommended version is baseline", exp.AssertRecommended(BaselineRole).Name)
+	assert.Equal(t, "version recommended for promotion is canary, not default", exp.AssertRecommended("default").Reason)
+
+	exp.Status.VersionRecommendedForPromotion = nil
+	assert.Equal(t, "no version recommended for promotion in experiment", exp.AssertRecommended("canary").Reason)
+}
+
+func TestResolveVersions(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	v, err := exp.ResolveVersions(BaselineRole)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default"}, v)
+	v, err = exp.ResolveVersions(CandidateRole)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"canary"}, v)
+
+	// a version named after a role takes precedence over the role
+	exp.Spec.VersionInfo.Candidates[0].Name = BaselineRole
+	v, err = exp.ResolveVersions(BaselineRole)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{BaselineRole}, v)
+
+	exp.Spec.VersionInfo.Candidates = nil
+	_, err = exp.ResolveVersions(CandidateRole)
+	assert.EqualError(t, err, "experiment has no candidate versions")
+
+	exp, err = getExp("experiment1")
+	assert.NoError(t, err)
+	_, err = exp.ResolveVersions(BaselineRole)
+	assert.EqualError(t, err, "unknown version baseline; versions in experiment: []")
+}
+
+func TestAssertMinIterations(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertMinIterations(10).Satisfied)
+	assert.Equal(t, "experiment completed 10 iterations, fewer than 11", exp.AssertMinIterations(11).Reason)
+
+	exp, err = getExp("experiment1")
+	assert.NoError(t, err)
+	assert.Equal(t, "experiment completed 0 iterations, fewer than 1", exp.AssertMinIterations(1).Reason)
+}
+
+func TestAssertMaxAge(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	created := exp.CreationTimestamp.Time
+	assert.True(t, exp.AssertMaxAge(time.Hour, created.Add(time.Minute)).Satisfied)
+	assert.Equal(t, "experiment was created 2h0m0s ago, more than 1h0m0s", exp.AssertMaxAge(time.Hour, created.Add(2*time.Hour)).Reason)
+
+	exp.CreationTimestamp = metav1.Time{}
+	assert.Equal(t, "experiment creation timestamp is unavailable", exp.AssertMaxAge(time.Hour, created).Reason)
+}
+
+func TestAssertResultSeverity(t *testing.T) {
+	res := &AssertResult{Results: []ConditionResult{
+		{Name: "a", Satisfied: true},
+		{Name: "b", Reason: "b is bad", Severity: SeverityWarn},
+		{Name: "c", Reason: "c does not apply", Skipped: true},
+	}}
+	assert.True(t, res.Satisfied())
+	assert.NoError(t, res.Err())
+	assert.Equal(t, 1, len(res.Warnings()))
+	assert.Equal(t, "[PASS] a\n[WARN] b: b is bad\n[SKIP] c: c does not apply\nAll conditions satisfied.\n1 warnings.\n", res.Checklist())
+
+	res.Results = append(res.Results, ConditionResult{Name: "d", Reason: "d is bad", Severity: SeverityError})
+	assert.False(t, res.Satisfied())
+	assert.EqualError(t, res.Err(), "d is bad")
+}
@@ -0,0 +1,76 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorKind classifies errors returned by this package, so that commands can report them consistently; for example, with distinct exit codes.
+type ErrorKind string
+
+const (
+	// KindAssertionFailed implies one or more asserted conditions are not satisfied
+	KindAssertionFailed ErrorKind = "AssertionFailed"
+	// KindUsage implies the request is invalid; for example, a malformed assertion
+	KindUsage ErrorKind = "Usage"
+	// KindNotFound implies the requested experiment does not exist
+	KindNotFound ErrorKind = "NotFound"
+	// KindCluster implies the K8s cluster could not be reached, or the request was not authorized
+	KindCluster ErrorKind = "Cluster"
+	// KindTimeout implies the request did not complete in time
+	KindTimeout ErrorKind = "Timeout"
+	// KindExperimentFailed implies the experiment itself has failed
+	KindExperimentFailed ErrorKind = "ExperimentFailed"
+)
+
+// Error is an error with an ErrorKind.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// Error returns the message of the underlying error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns an error of the given kind with the given message.
+func NewError(kind ErrorKind, message string) *Error {
+	return &Error{Kind: kind, Err: errors.New(message)}
+}
+
+// KindOf returns the kind of err if err, or any error it wraps, is an *Error. Otherwise, it returns the empty ErrorKind.
+func KindOf(err error) ErrorKind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return ""
+}
+
+// clusterError classifies an error returned while communicating with the K8s cluster as a timeout, not found, or cluster error.
+func clusterError(err error) error {
+	if err == nil || KindOf(err) != "" {
+		return err
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		apierrors.IsTimeout(err),
+		apierrors.IsServerTimeout(err),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return &Error{Kind: KindTimeout, Err: err}
+	case apierrors.IsNotFound(err):
+		return &Error{Kind: KindNotFound, Err: err}
+	default:
+		return &Error{Kind: KindCluster, Err: err}
+	}
+}
@@ -0,0 +1,61 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+/* Tests */
+
+func TestKindOf(t *testing.T) {
+	err := NewError(KindNotFound, "not found")
+	assert.Equal(t, KindNotFound, KindOf(err))
+	assert.Equal(t, "not found", err.Error())
+	assert.Equal(t, KindNotFound, KindOf(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, ErrorKind(""), KindOf(errors.New("untyped")))
+	assert.Equal(t, ErrorKind(""), KindOf(nil))
+}
+
+func TestClusterError(t *testing.T) {
+	gr := schema.GroupResource{Group: "iter8.tools", Resource: "experiments"}
+	for _, tc := range []struct {
+		err  error
+		kind ErrorKind
+	}{
+		{err: context.DeadlineExceeded, kind: KindTimeout},
+		{err: apierrors.NewTimeoutError("slow", 1), kind: KindTimeout},
+		{err: apierrors.NewServerTimeout(gr, "list", 1), kind: KindTimeout},
+		{err: apierrors.NewNotFound(gr, "exp"), kind: KindNotFound},
+		{err: apierrors.NewUnauthorized("who are you"), kind: KindCluster},
+		{err: apierrors.NewForbidden(gr, "exp", errors.New("no")), kind: KindCluster},
+		{err: errors.New("connection refused"), kind: KindCluster},
+		{err: NewError(KindUsage, "bad"), kind: KindUsage},
+	} {
+		assert.Equal(t, tc.kind, KindOf(clusterError(tc.err)), tc.err.Error())
+	}
+	assert.NoError(t, clusterError(nil))
+}
+
+func TestFailed(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.False(t, exp.Failed())
+	exp.Status.MarkCondition(v2alpha2.ExperimentConditionExperimentFailed, corev1.ConditionTrue, "HandlerFailed", "handler failed")
+	assert.True(t, exp.Failed())
+	assert.False(t, (*Experiment)(nil).Failed())
+}
+
+func TestAssertErrKind(t *testing.T) {
+	exp, err := getExp("experiment1")
+	assert.NoError(t, err)
+	err = exp.Assert([]ConditionType{Completed}).Err()
+	assert.Equal(t, KindAssertionFailed, KindOf(err))
+}
@@ -0,0 +1,81 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventSource is the component reported as the source of K8s events emitted by iter8ctl.
+const EventSource = "iter8ctl"
+
+// Reasons of K8s events emitted by iter8ctl.
+const (
+	// ReasonAssertionSatisfied implies every asserted condition is satisfied
+	ReasonAssertionSatisfied = "AssertionSatisfied"
+	// ReasonAssertionFailed implies one or more asserted conditions are not satisfied
+	ReasonAssertionFailed = "AssertionFailed"
+)
+
+// NewEvent returns a K8s event of the given type (corev1.EventTypeNormal or corev1.EventTypeWarning) on the experiment, which occurred at time t.
+func (e *Experiment) NewEvent(eventType string, reason string, message string, t time.Time) *corev1.Event {
+	now := metav1.NewTime(t)
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: e.Name + ".",
+			Namespace:    e.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      v2alpha2.GroupVersion.String(),
+			Kind:            "Experiment",
+			Namespace:       e.Namespace,
+			Name:            e.Name,
+			UID:             e.UID,
+			ResourceVersion: e.ResourceVersion,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Source:         corev1.EventSource{Component: EventSource},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
+
+// NewAssertEvent returns a K8s event on the experiment recording the outcome of an assertion, and the winner of the experiment, at time t.
+// The event is a warning if the assertion is not satisfied. For example,
+//  AssertionFailed: 1 of 2 conditions not satisfied: winnerFound (no winner found in experiment); no winner found
+func (e *Experiment) NewAssertEvent(r *AssertResult, t time.Time) *corev1.Event {
+	eventType, reason := corev1.EventTypeNormal, ReasonAssertionSatisfied
+	if !r.Satisfied() {
+		eventType, reason = corev1.EventTypeWarning, ReasonAssertionFailed
+	}
+	return e.NewEvent(eventType, reason, r.Summary()+"; "+e.winnerSummary(), t)
+}
+
+// winnerSummary describes the winner of the experiment.
+func (e *Experiment) winnerSummary() string {
+	a := e.GetAnalysis()
+	switch {
+	case !a.WinnerAssessed:
+		return "winner unavailable"
+	case a.Winner == nil:
+		return "no winner found"
+	default:
+		return fmt.Sprintf("winner is %s", *a.Winner)
+	}
+}
+
+// EmitEvent creates the event in the K8s cluster.
+func EmitEvent(ctx context.Context, ev *corev1.Event) error {
+	rc, err := GetClient()
+	if err != nil {
+		return clusterError(err)
+	}
+	return clusterError(rc.Create(ctx, ev))
+}
@@ -0,0 +1,49 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewAssertEvent(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	ev := exp.NewAssertEvent(exp.Assert([]ConditionType{Completed, WinnerFound}), now)
+	assert.Equal(t, corev1.EventTypeNormal, ev.Type)
+	assert.Equal(t, ReasonAssertionSatisfied, ev.Reason)
+	assert.Equal(t, "all 2 conditions satisfied; winner is canary", ev.Message)
+	assert.Equal(t, "sklearn-iris-experiment-1.", ev.GenerateName)
+	assert.Equal(t, "kfserving-test", ev.Namespace)
+	assert.Equal(t, "iter8.tools/v2alpha2", ev.InvolvedObject.APIVersion)
+	assert.Equal(t, EventSource, ev.Source.Component)
+	assert.True(t, ev.LastTimestamp.Time.Equal(now))
+
+	r := &AssertResult{Results: []ConditionResult{exp.AssertWinner("default"), exp.AssertMinIterations(5)}}
+	ev = exp.NewAssertEvent(r, now)
+	assert.Equal(t, corev1.EventTypeWarning, ev.Type)
+	assert.Equal(t, ReasonAssertionFailed, ev.Reason)
+	assert.Equal(t, "1 of 2 conditions not satisfied: winner is default (winning version is canary, not default); winner is canary", ev.Message)
+
+	exp, err = getExp("experiment1")
+	assert.NoError(t, err)
+	assert.Equal(t, "all 0 conditions satisfied; winner unavailable", exp.NewAssertEvent(&AssertResult{}, now).Message)
+}
+
+func TestEmitEvent(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	rc := testutil.WithFakeObjects(t, &GetClient, nil)
+
+	assert.NoError(t, EmitEvent(context.Background(), exp.NewEvent(corev1.EventTypeNormal, "Promoted", "promoted canary", time.Now())))
+	events := &corev1.EventList{}
+	assert.NoError(t, rc.List(context.Background(), events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "promoted canary", events.Items[0].Message)
+}
@@ -0,0 +1,161 @@
+package experiment
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/inf.v0"
+)
+
+// resolveMetricName returns the name under which the referenced metric appears in the experiment.
+// Metric references may be of the form "name" or "namespace/name"; a reference matches a metric with the same name in any namespace if there is no exact match.
+// It returns an error if there is no exact match, and metrics with the same name appear in more than one namespace.
+func (e *Experiment) resolveMetricName(ref string) (string, error) {
+	names := e.GetMetricNames()
+	if containsString(names, ref) {
+		return ref, nil
+	}
+	base := ref[strings.LastIndex(ref, "/")+1:]
+	var matches []string
+	for _, name := range names {
+		if name[strings.LastIndex(name, "/")+1:] == base {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ref, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("metric %s is ambiguous; it matches [%s]", ref, strings.Join(matches, ", "))
+	}
+}
+
+// GetRequestCount returns the value of the experiment's request count metric (spec.criteria.requestCount) for the given version.
+// It returns nil if the experiment does not specify a request count metric, if the metric is ambiguous, or if its value is unavailable.
+func (e *Experiment) GetRequestCount(version string) *inf.Dec {
+	if e.Spec.Criteria == nil || e.Spec.Criteria.RequestCount == nil {
+		return nil
+	}
+	name, err := e.resolveMetricName(*e.Spec.Criteria.RequestCount)
+	if err != nil {
+		return nil
+	}
+	return e.getMetricValue(name, version)
+}
+
+// GetSampleSize returns the number of data points over which the value of the given metric for the given version is computed.
+// The sample size recorded along with the aggregated metric value takes precedence; otherwise, the value of the metric's sampleSize metric is used.
+// It returns nil if the sample size is unavailable, or if the sampleSize metric is ambiguous.
+func (e *Experiment) GetSampleSize(metric string, version string) *inf.Dec {
+	if ss := e.getRecordedSampleSize(metric, version); ss != nil {
+		return ss
+	}
+	if ref := e.getSampleSizeMetric(metric); ref != nil {
+		if name, err := e.resolveMetricName(*ref); err == nil {
+			return e.getMetricValue(name, version)
+		}
+	}
+	return nil
+}
+
+// getRecordedSampleSize returns the sample size recorded along with the aggregated value of the given metric for the given version, or nil if none is recorded.
+func (e *Experiment) getRecordedSampleSize(metric string, version string) *inf.Dec {
+	if a := e.Status.Analysis; a != nil && a.AggregatedMetrics != nil {
+		if vals, ok := a.AggregatedMetrics.Data[metric]; ok {
+			if val, ok := vals.Data[version]; ok && val.SampleSize != nil {
+				return inf.NewDec(int64(*val.SampleSize), 0)
+			}
+		}
+	}
+	return nil
+}
+
+// getSampleSizeMetric returns the reference to the sampleSize metric of the given metric, or nil if it has none.
+func (e *Experiment) getSampleSizeMetric(metric string) *string {
+	for _, mi := range e.Status.Metrics {
+		if mi.Name == metric {
+			return mi.MetricObj.Spec.SampleSize
+		}
+	}
+	return nil
+}
+
+// AssertMinSampleSize verifies that every version has at least n requests according to the experiment's request count metric,
+// and that every metric value with a known sample size is computed over at least n data points.
+func (e *Experiment) AssertMinSampleSize(n int64) ConditionResult {
+	res := ConditionResult{Name: fmt.Sprintf("sample size of at least %v per version", n)}
+	min := inf.NewDec(n, 0)
+	versions := e.GetVersions()
+	if len(versions) == 0 {
+		res.Reason = "experiment has no versions"
+		return res
+	}
+	if err := e.resolveEvidenceMetrics(); err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+
+	var reasons []string
+	checked := 0
+	for _, version := range versions {
+		if e.Spec.Criteria != nil && e.Spec.Criteria.RequestCount != nil {
+			checked++
+			if rc := e.GetRequestCount(version); rc == nil {
+				reasons = append(reasons, fmt.Sprintf("request count for version %s is unavailable", version))
+			} else if rc.Cmp(min) < 0 {
+				reasons = append(reasons, fmt.Sprintf("version %s has request count %s, fewer than %v", version, rc, n))
+			}
+		}
+		for _, mi := range e.Status.Metrics {
+			// values without a recorded sample size, whose sample size is the request count, have been verified above
+			ref := e.getSampleSizeMetric(mi.Name)
+			if e.getRecordedSampleSize(mi.Name, version) == nil && (ref == nil || e.isRequestCountMetric(*ref)) {
+				continue
+			}
+			checked++
+			if ss := e.GetSampleSize(mi.Name, version); ss == nil {
+				reasons = append(reasons, fmt.Sprintf("sample size of %s for version %s is unavailable", mi.Name, version))
+			} else if ss.Cmp(min) < 0 {
+				reasons = append(reasons, fmt.Sprintf("%s for version %s has sample size %s, fewer than %v", mi.Name, version, ss, n))
+			}
+		}
+	}
+	if checked == 0 {
+		res.Reason = "experiment has neither a request count metric nor metrics with sample sizes"
+		return res
+	}
+	res.Satisfied = len(reasons) == 0
+	res.Reason = strings.Join(reasons, "; ")
+	return res
+}
+
+// resolveEvidenceMetrics returns an error if the request count metric of the experiment, or the sampleSize metric of any of its metrics, is ambiguous.
+func (e *Experiment) resolveEvidenceMetrics() error {
+	var refs []string
+	if e.Spec.Criteria != nil && e.Spec.Criteria.RequestCount != nil {
+		refs = append(refs, *e.Spec.Criteria.RequestCount)
+	}
+	for _, mi := range e.Status.Metrics {
+		if ref := mi.MetricObj.Spec.SampleSize; ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	for _, ref := range refs {
+		if _, err := e.resolveMetricName(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRequestCountMetric indicates if the referenced metric is the experiment's request count metric.
+func (e *Experiment) isRequestCountMetric(ref string) bool {
+	if e.Spec.Criteria == nil || e.Spec.Criteria.RequestCount == nil {
+		return false
+	}
+	name, err := e.resolveMetricName(ref)
+	requestCount, rcErr := e.resolveMetricName(*e.Spec.Criteria.RequestCount)
+	return err == nil && rcErr == nil && name == requestCount
+}
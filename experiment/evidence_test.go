@@ -0,0 +1,80 @@
+package experiment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestGetRequestCount(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.Equal(t, "57.714400001", exp.GetRequestCount("canary").String())
+	assert.Nil(t, exp.GetRequestCount("perfect"))
+
+	exp.Spec.Criteria.RequestCount = nil
+	assert.Nil(t, exp.GetRequestCount("canary"))
+}
+
+func TestResolveMetricName(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	name, err := exp.resolveMetricName("iter8-system/request-count")
+	assert.NoError(t, err)
+	assert.Equal(t, "request-count", name)
+
+	// the base name matches metrics in more than one namespace
+	data := exp.Status.Analysis.AggregatedMetrics.Data
+	data["iter8-kfserving/request-count"] = data["request-count"]
+	_, err = exp.resolveMetricName("iter8-system/request-count")
+	assert.EqualError(t, err, "metric iter8-system/request-count is ambiguous; it matches [request-count, iter8-kfserving/request-count]")
+	name, err = exp.resolveMetricName("request-count")
+	assert.NoError(t, err)
+	assert.Equal(t, "request-count", name)
+
+	rc := "iter8-system/request-count"
+	exp.Spec.Criteria.RequestCount = &rc
+	assert.Nil(t, exp.GetRequestCount("canary"))
+	res := exp.AssertMinSampleSize(10)
+	assert.False(t, res.Satisfied)
+	assert.Equal(t, "metric iter8-system/request-count is ambiguous; it matches [request-count, iter8-kfserving/request-count]", res.Reason)
+}
+
+func TestGetSampleSize(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.Equal(t, "117.444444445", exp.GetSampleSize("mean-latency", "default").String())
+	assert.Nil(t, exp.GetSampleSize("request-count", "default"))
+
+	ss := int32(42)
+	d := exp.Status.Analysis.AggregatedMetrics.Data["mean-latency"].Data["default"]
+	d.SampleSize = &ss
+	exp.Status.Analysis.AggregatedMetrics.Data["mean-latency"].Data["default"] = d
+	assert.Equal(t, "42", exp.GetSampleSize("mean-latency", "default").String())
+}
+
+func TestAssertMinSampleSize(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertMinSampleSize(50).Satisfied)
+	res := exp.AssertMinSampleSize(100)
+	assert.False(t, res.Satisfied)
+	assert.Equal(t, "sample size of at least 100 per version", res.Name)
+	assert.Equal(t, "version canary has request count 57.714400001, fewer than 100", res.Reason)
+
+	// a sample size recorded with a metric value is verified as well
+	ss := int32(42)
+	d := exp.Status.Analysis.AggregatedMetrics.Data["error-rate"].Data["default"]
+	d.SampleSize = &ss
+	exp.Status.Analysis.AggregatedMetrics.Data["error-rate"].Data["default"] = d
+	assert.Equal(t, "error-rate for version default has sample size 42, fewer than 50", exp.AssertMinSampleSize(50).Reason)
+
+	delete(exp.Status.Analysis.AggregatedMetrics.Data, "request-count")
+	assert.Equal(t, "request count for version default is unavailable; request count for version canary is unavailable", exp.AssertMinSampleSize(10).Reason)
+
+	exp, err = getExp("experiment1")
+	assert.NoError(t, err)
+	assert.Equal(t, "experiment has no versions", exp.AssertMinSampleSize(10).Reason)
+}
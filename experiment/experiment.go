@@ -4,12 +4,12 @@ package experiment
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/iter8-tools/etc3/api/v2alpha2"
 	tasks "github.com/iter8-tools/handler/tasks"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/inf.v0"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -54,7 +54,7 @@ var GetConfig = func() (*rest.Config, error) {
 }
 
 // GetClient constructs and returns a K8s client.
-// The returned client has experiment types registered.
+// The returned client has experiment and K8s event types registered.
 var GetClient = func() (rc client.Client, err error) {
 	var restConf *rest.Config
 	restConf, err = GetConfig()
@@ -62,6 +62,41 @@ var GetClient = func() (rc client.Client, err error) {
 		return nil, err
 	}
 
+	var scheme *runtime.Scheme
+	if scheme, err = newScheme(); err == nil {
+		rc, err = client.New(restConf, client.Options{
+			Scheme: scheme,
+		})
+		if err == nil {
+			return rc, nil
+		}
+	}
+	return nil, errors.New("cannot get client using rest config")
+}
+
+// GetWatchClient constructs and returns a K8s client which supports watches.
+// The returned client has experiment and K8s event types registered.
+var GetWatchClient = func() (rc client.WithWatch, err error) {
+	var restConf *rest.Config
+	restConf, err = GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var scheme *runtime.Scheme
+	if scheme, err = newScheme(); err == nil {
+		rc, err = client.NewWithWatch(restConf, client.Options{
+			Scheme: scheme,
+		})
+		if err == nil {
+			return rc, nil
+		}
+	}
+	return nil, errors.New("cannot get client using rest config")
+}
+
+// newScheme returns a scheme with experiment and K8s event types registered.
+func newScheme() (*runtime.Scheme, error) {
 	var addKnownTypes = func(scheme *runtime.Scheme) error {
 		// register iter8.GroupVersion and type
 		metav1.AddToGroupVersion(scheme, v2alpha2.GroupVersion)
@@ -72,17 +107,10 @@ var GetClient = func() (rc client.Client, err error) {
 
 	var schemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
 	scheme := runtime.NewScheme()
-	err = schemeBuilder.AddToScheme(scheme)
-
-	if err == nil {
-		rc, err = client.New(restConf, client.Options{
-			Scheme: scheme,
-		})
-		if err == nil {
-			return rc, nil
-		}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("cannot get client using rest config")
+	return scheme, schemeBuilder.AddToScheme(scheme)
 }
 
 // GetExperiment gets the experiment from cluster
@@ -96,13 +124,14 @@ func GetExperiment(latest bool, name string, namespace string) (*Experiment, err
 	if rc, err = GetClient(); err == nil {
 		err = rc.List(context.Background(), &results, &client.ListOptions{})
 	}
+	err = clusterError(err)
 
 	// get latest experiment
 	if latest && err == nil {
 		if len(results.Items) > 0 {
 			exp = &results.Items[len(results.Items)-1]
 		} else {
-			err = errors.New("No experiments found in cluster")
+			err = NewError(KindNotFound, "No experiments found in cluster")
 		}
 	}
 
@@ -115,7 +144,7 @@ func GetExperiment(latest bool, name string, namespace string) (*Experiment, err
 			}
 		}
 		if exp == nil {
-			err = errors.New("Experiment " + name + " not found in namespace " + namespace)
+			err = NewError(KindNotFound, "Experiment "+name+" not found in namespace "+namespace)
 		}
 	}
 
@@ -130,6 +159,23 @@ func GetExperiment(latest bool, name string, namespace string) (*Experiment, err
 	}, nil
 }
 
+// ListExperiments returns the experiments in the given namespace, or in all namespaces if namespace is empty.
+func ListExperiments(ctx context.Context, namespace string) ([]*Experiment, error) {
+	rc, err := GetClient()
+	if err != nil {
+		return nil, clusterError(err)
+	}
+	list := v2alpha2.ExperimentList{}
+	if err := rc.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, clusterError(err)
+	}
+	exps := make([]*Experiment, len(list.Items))
+	for i := range list.Items {
+		exps[i] = &Experiment{list.Items[i]}
+	}
+	return exps, nil
+}
+
 // Started indicates if at least one iteration of the experiment has completed.
 func (e *Experiment) Started() bool {
 	if e == nil {
@@ -148,6 +194,15 @@ func (e *Experiment) Completed() bool {
 	return c != nil && c.IsTrue()
 }
 
+// Failed indicates if the experiment has failed.
+func (e *Experiment) Failed() bool {
+	if e == nil {
+		return false
+	}
+	c := e.Status.GetCondition(v2alpha2.ExperimentConditionExperimentFailed)
+	return c != nil && c.IsTrue()
+}
+
 // WinnerFound indicates if the experiment has found a winning version (winner).
 func (e *Experiment) WinnerFound() bool {
 	if e == nil {
@@ -173,20 +228,21 @@ func (e *Experiment) GetVersions() []string {
 	return versions
 }
 
-// GetMetricStr returns the metric value as a string for a given metric and a given version.
-func (e *Experiment) GetMetricStr(metric string, version string) string {
-	am := e.Status.Analysis.AggregatedMetrics
-	if am == nil {
-		return "unavailable"
-	}
-	if vals, ok := am.Data[metric]; ok {
-		if val, ok := vals.Data[version]; ok {
-			if val.Value != nil {
-				z := new(inf.Dec).Round(val.Value.AsDec(), 3, inf.RoundCeil)
-				return z.String()
-			}
+// GetWeight returns the traffic weight currently applied to a version, or nil if it is unavailable.
+func (e *Experiment) GetWeight(version string) *int32 {
+	for i := range e.Status.CurrentWeightDistribution {
+		if w := &e.Status.CurrentWeightDistribution[i]; w.Name == version {
+			return &w.Value
 		}
 	}
+	return nil
+}
+
+// GetMetricStr returns the metric value as a string for a given metric and a given version, or "unavailable" if there is no such value.
+func (e *Experiment) GetMetricStr(metric string, version string) string {
+	if z := e.GetMetricDec(metric, version); z != nil {
+		return z.String()
+	}
 	return "unavailable"
 }
 
@@ -204,7 +260,7 @@ func (e *Experiment) GetMetricStrs(metric string) []string {
 func GetMetricNameAndUnits(metricInfo v2alpha2.MetricInfo) string {
 	r := metricInfo.Name
 	if metricInfo.MetricObj.Spec.Units != nil {
-		r += fmt.Sprintf(" (" + *metricInfo.MetricObj.Spec.Units + ")")
+		r += " (" + *metricInfo.MetricObj.Spec.Units + ")"
 	}
 	return r
 }
@@ -226,20 +282,7 @@ func StringifyObjective(objective v2alpha2.Objective) string {
 
 // GetSatisfyStr returns a true/false/unavailable valued string denotating if a version satisfies the objective.
 func (e *Experiment) GetSatisfyStr(objectiveIndex int, version string) string {
-	ana := e.Status.Analysis
-	if ana == nil {
-		return "unavailable"
-	}
-	va := ana.VersionAssessments
-	if va == nil {
-		return "unavailable"
-	}
-	if vals, ok := va.Data[version]; ok {
-		if len(vals) > objectiveIndex {
-			return fmt.Sprintf("%v", vals[objectiveIndex])
-		}
-	}
-	return "unavailable"
+	return e.GetSatisfaction(objectiveIndex, version).String()
 }
 
 // GetSatisfyStrs returns a slice of true/false/unavailable valued strings for an objective denoting if it is satisfied by versions.
@@ -264,82 +307,32 @@ func StringifyReward(reward v2alpha2.Reward) string {
 	return r
 }
 
-// GetMetricDec returns the metric value as a string for a given metric and a given version.
+// GetMetricDec returns the metric value rounded to 3 decimal places for a given metric and a given version, or nil if it is unavailable.
 func (e *Experiment) GetMetricDec(metric string, version string) *inf.Dec {
-	am := e.Status.Analysis.AggregatedMetrics
-	if am == nil {
-		return nil
-	}
-	if vals, ok := am.Data[metric]; ok {
-		if val, ok := vals.Data[version]; ok {
-			if val.Value != nil {
-				z := new(inf.Dec).Round(val.Value.AsDec(), 3, inf.RoundCeil)
-				return z
-			}
-		}
+	if val := e.getMetricValue(metric, version); val != nil {
+		return new(inf.Dec).Round(val, 3, inf.RoundCeil)
 	}
 	return nil
 }
 
-// GetAnnotatedMetricStrs returns a slice of values for a reward
-func (e *Experiment) GetAnnotatedMetricStrs(reward v2alpha2.Reward) []string {
-	versions := e.GetVersions()
-	row := make([]string, len(versions))
-	var currentBestIndex *int
-	var currentBestValue *inf.Dec
-	for i, v := range versions {
-		val := e.GetMetricDec(reward.Metric, v)
+// getMetricValue returns the unrounded metric value for a given metric and a given version, or nil if it is unavailable.
+func (e *Experiment) getMetricValue(metric string, version string) *inf.Dec {
+	return e.GetMetricValue(metric, version).Value
+}
 
-		if val == nil {
+// GetAnnotatedMetricStrs returns a slice of values for a reward, whose elements correspond to versions. The best version is marked with a '*'.
+func (e *Experiment) GetAnnotatedMetricStrs(reward v2alpha2.Reward) []string {
+	ra := e.GetRewardAnalysis(reward)
+	row := make([]string, len(ra.Values))
+	for i, v := range ra.Values {
+		if !v.Available() {
 			row[i] = "unavailable"
 			continue
 		}
-
-		row[i] = val.String()
-
-		// set currentBest if not already set
-		if currentBestIndex == nil {
-			currentBestIndex, currentBestValue = &i, val
-			continue
+		row[i] = new(inf.Dec).Round(v.Value, 3, inf.RoundCeil).String()
+		if v.Version == ra.Best() {
+			row[i] += " *"
 		}
-
-		// update currentBest
-
-		if reward.PreferredDirection == v2alpha2.PreferredDirectionHigher {
-			if -1 == currentBestValue.Cmp(val) {
-				currentBestIndex, currentBestValue = &i, val
-			}
-			continue
-		}
-
-		// reward.PreferredDirection == v2alpha2.PreferredDirectionLower
-		if 1 == currentBestValue.Cmp(val) {
-			currentBestIndex, currentBestValue = &i, val
-		}
-	}
-
-	// mark current best with '*'
-	if currentBestIndex != nil {
-		row[*currentBestIndex] = row[*currentBestIndex] + " *"
 	}
 	return row
 }
-
-// Assert verifies a given set of conditions for the experiment.
-func (e *Experiment) Assert(conditions []ConditionType) error {
-	for _, cond := range conditions {
-		switch cond {
-		case Completed:
-			if !e.Completed() {
-				return errors.New("experiment has not completed")
-			}
-		case WinnerFound:
-			if !e.WinnerFound() {
-				return errors.New("no winner found in experiment")
-			}
-		default:
-			return errors.New("unsupported condition found in assertion")
-		}
-	}
-	return nil
-}
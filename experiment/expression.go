@@ -0,0 +1,211 @@
+package experiment
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/parser"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Expression is a type checked CEL (https://github.com/google/cel-spec) expression that evaluates to a bool.
+// Expressions are evaluated against the view of an experiment returned by Experiment.ExpressionView, which declares the following variables.
+//  name                 string                          experiment name
+//  namespace            string                          experiment namespace
+//  spec                 map(string, dyn)                experiment spec, as it appears in the experiment YAML
+//  status               map(string, dyn)                experiment status, as it appears in the experiment YAML
+//  stage                string                          experiment stage; empty if unknown
+//  completed            bool                            true if the experiment has completed
+//  completedIterations  int                             number of completed iterations
+//  versions             list(string)                    baseline followed by candidates
+//  winnerFound          bool                            true if the experiment has found a winner
+//  winner               string                          winning version; empty if no winner is found
+//  recommended          string                          version recommended for promotion; empty if unavailable
+//  metrics              map(string, map(string, double)) metric name -> version -> value; unavailable values are absent
+//  objectives           map(string, list(bool))         version -> satisfaction of each objective, in spec order
+//  objectivesSatisfied  map(string, bool)               version -> true if the version satisfies every objective
+// For example, the expression
+//  winner == "canary" && metrics["iter8-istio/error-rate"]["canary"] < 0.01
+// holds if the canary version won and its error rate is below 1%.
+type Expression struct {
+	source  string
+	ast     *cel.Ast
+	program cel.Program
+}
+
+// expressionDeclarations are the variables available in expressions; keep in sync with Experiment.ExpressionView.
+var expressionDeclarations = []*exprpb.Decl{
+	decls.NewVar("name", decls.String),
+	decls.NewVar("namespace", decls.String),
+	decls.NewVar("spec", decls.NewMapType(decls.String, decls.Dyn)),
+	decls.NewVar("status", decls.NewMapType(decls.String, decls.Dyn)),
+	decls.NewVar("stage", decls.String),
+	decls.NewVar("completed", decls.Bool),
+	decls.NewVar("completedIterations", decls.Int),
+	decls.NewVar("versions", decls.NewListType(decls.String)),
+	decls.NewVar("winnerFound", decls.Bool),
+	decls.NewVar("winner", decls.String),
+	decls.NewVar("recommended", decls.String),
+	decls.NewVar("metrics", decls.NewMapType(decls.String, decls.NewMapType(decls.String, decls.Double))),
+	decls.NewVar("objectives", decls.NewMapType(decls.String, decls.NewListType(decls.Bool))),
+	decls.NewVar("objectivesSatisfied", decls.NewMapType(decls.String, decls.Bool)),
+}
+
+// CompileExpression parses and type checks the given expression, which must evaluate to a bool.
+func CompileExpression(source string) (*Expression, error) {
+	env, err := cel.NewEnv(cel.Declarations(expressionDeclarations...))
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(source)
+	if iss != nil && iss.Err() != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", source, iss.Err())
+	}
+	if !isBoolType(ast.ResultType()) {
+		return nil, fmt.Errorf("invalid expression %q: expected a bool result, found %s", source, cel.FormatType(ast.ResultType()))
+	}
+	prg, err := env.Program(ast, cel.EvalOptions(cel.OptExhaustiveEval))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", source, err)
+	}
+	return &Expression{
+		source:  source,
+		ast:     ast,
+		program: prg,
+	}, nil
+}
+
+// isBoolType indicates if t is the bool type.
+func isBoolType(t *exprpb.Type) bool {
+	return t.GetPrimitive() == exprpb.Type_BOOL
+}
+
+// String returns the source of the expression.
+func (x *Expression) String() string {
+	return x.source
+}
+
+// ExpressionView returns the view of the experiment against which expressions are evaluated.
+// The variables in the view are documented with the Expression type.
+func (e *Experiment) ExpressionView() map[string]interface{} {
+	view := map[string]interface{}{
+		"name":                e.Name,
+		"namespace":           e.Namespace,
+		"spec":                toJSONMap(e.Spec),
+		"status":              toJSONMap(e.Status),
+		"stage":               "",
+		"completed":           e.Completed(),
+		"completedIterations": int64(0),
+		"winnerFound":         e.WinnerFound(),
+		"winner":              "",
+		"recommended":         "",
+	}
+	if e.Status.Stage != nil {
+		view["stage"] = string(*e.Status.Stage)
+	}
+	if e.Status.CompletedIterations != nil {
+		view["completedIterations"] = int64(*e.Status.CompletedIterations)
+	}
+	if e.WinnerFound() && e.Status.Analysis.WinnerAssessment.Data.Winner != nil {
+		view["winner"] = *e.Status.Analysis.WinnerAssessment.Data.Winner
+	}
+	if e.Status.VersionRecommendedForPromotion != nil {
+		view["recommended"] = *e.Status.VersionRecommendedForPromotion
+	}
+
+	versions := e.GetVersions()
+	if versions == nil {
+		versions = []string{}
+	}
+	view["versions"] = versions
+
+	metrics := map[string]map[string]float64{}
+	for _, metric := range e.GetMetricNames() {
+		metrics[metric] = map[string]float64{}
+		for _, version := range versions {
+			if val := e.getMetricValue(metric, version); val != nil {
+				if f, err := strconv.ParseFloat(val.String(), 64); err == nil {
+					metrics[metric][version] = f
+				}
+			}
+		}
+	}
+	view["metrics"] = metrics
+
+	objectives := map[string][]bool{}
+	objectivesSatisfied := map[string]bool{}
+	if a := e.Status.Analysis; a != nil && a.VersionAssessments != nil {
+		for version, vals := range a.VersionAssessments.Data {
+			objectives[version] = vals
+			satisfied := true
+			for _, val := range vals {
+				satisfied = satisfied && val
+			}
+			objectivesSatisfied[version] = satisfied
+		}
+	}
+	view["objectives"] = objectives
+	view["objectivesSatisfied"] = objectivesSatisfied
+
+	return view
+}
+
+// toJSONMap converts v into the generic map representation obtained by round tripping it through JSON.
+func toJSONMap(v interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	if b, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(b, &m)
+	}
+	return m
+}
+
+// AssertExpression evaluates the expression against the experiment.
+// If the expression does not hold, the reason identifies the failing sub-expressions of the top level conjunction.
+func (e *Experiment) AssertExpression(x *Expression) ConditionResult {
+	res := ConditionResult{Name: x.source}
+	out, det, err := x.program.Eval(e.ExpressionView())
+	if err == nil && out == types.True {
+		res.Satisfied = true
+		return res
+	}
+	var failing []string
+	if det != nil {
+		collectFailing(x.ast.Expr(), x.ast.SourceInfo(), det, &failing)
+	}
+	if len(failing) == 0 {
+		failing = []string{x.source}
+	}
+	if err != nil {
+		res.Reason = fmt.Sprintf("cannot evaluate expression: %v; failing: %s", err, strings.Join(failing, ", "))
+	} else {
+		res.Reason = "expression does not hold; failing: " + strings.Join(failing, ", ")
+	}
+	return res
+}
+
+// collectFailing walks the top level conjunction rooted at expr and appends the source of every operand that did not evaluate to true.
+func collectFailing(expr *exprpb.Expr, info *exprpb.SourceInfo, det *cel.EvalDetails, failing *[]string) {
+	if call := expr.GetCallExpr(); call != nil && call.Function == "_&&_" {
+		for _, arg := range call.Args {
+			collectFailing(arg, info, det, failing)
+		}
+		return
+	}
+	val, ok := det.State().Value(expr.Id)
+	if !ok || val == types.True {
+		return
+	}
+	src, err := parser.Unparse(expr, info)
+	if err != nil {
+		return
+	}
+	if types.IsError(val) {
+		src += fmt.Sprintf(" (%v)", val)
+	}
+	*failing = append(*failing, src)
+}
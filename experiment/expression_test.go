@@ -0,0 +1,58 @@
+package experiment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestCompileExpressionErrors(t *testing.T) {
+	for _, s := range []string{
+		"winner ==",             // syntax error
+		"winner == 3",           // type error
+		"unknownVariable",       // undeclared variable
+		"completedIterations",   // not a bool
+		"metrics[\"a\"][\"b\"]", // not a bool
+	} {
+		_, err := CompileExpression(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAssertExpression(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		expression string
+		satisfied  bool
+		reason     string
+	}{
+		{`winner == "canary" && completed`, true, ""},
+		{`metrics["error-rate"]["canary"] < 0.01 && objectivesSatisfied["canary"]`, true, ""},
+		{`completedIterations >= 10 && spec.strategy.testingPattern == "Canary"`, true, ""},
+		{`versions.size() == 2 && objectives["default"][1]`, true, ""},
+		{`recommended == "canary" && stage == ""`, true, ""},
+		{`completed && winner == "default" && completedIterations > 20`, false, `expression does not hold; failing: winner == "default", completedIterations > 20`},
+		{`winner == "default" || winner == "perfect"`, false, `expression does not hold; failing: winner == "default" || winner == "perfect"`},
+		{`completed && metrics["error-rate"]["perfect"] < 0.01`, false, `cannot evaluate expression: no such key: perfect; failing: metrics["error-rate"]["perfect"] < 0.01 (no such key: perfect)`},
+	} {
+		x, err := CompileExpression(tc.expression)
+		assert.NoError(t, err, tc.expression)
+		res := exp.AssertExpression(x)
+		assert.Equal(t, tc.expression, res.Name)
+		assert.Equal(t, tc.satisfied, res.Satisfied, tc.expression)
+		assert.Equal(t, tc.reason, res.Reason, tc.expression)
+	}
+}
+
+func TestExpressionViewNotStarted(t *testing.T) {
+	exp, err := getExp("experiment1")
+	assert.NoError(t, err)
+
+	x, err := CompileExpression(`!winnerFound && winner == "" && versions.size() == 0 && completedIterations == 0`)
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertExpression(x).Satisfied)
+}
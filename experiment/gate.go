@@ -0,0 +1,98 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// GateAnnotationPrefix is the prefix of the annotations recording gate decisions on experiments; the name of the gate follows the prefix.
+const GateAnnotationPrefix = "gates.iter8ctl.iter8.tools/"
+
+// GateResult is the decision of a CI/CD gate.
+type GateResult string
+
+const (
+	// GatePassed implies the gate passed
+	GatePassed GateResult = "passed"
+	// GateFailed implies the gate failed
+	GateFailed GateResult = "failed"
+)
+
+// ParseGateResult returns the gate result named by s.
+func ParseGateResult(s string) (GateResult, error) {
+	switch GateResult(s) {
+	case GatePassed, GateFailed:
+		return GateResult(s), nil
+	default:
+		return "", fmt.Errorf("invalid gate result: %v; expected one of %v, %v", s, GatePassed, GateFailed)
+	}
+}
+
+// GateDecision is the decision of a CI/CD gate for an experiment, which is recorded as the JSON value of an annotation on the experiment.
+type GateDecision struct {
+	// Gate is the name of the gate; it is part of the annotation key, and is not included in the value
+	Gate   string      `json:"-"`
+	Result GateResult  `json:"result"`
+	Time   metav1.Time `json:"time"`
+	// PipelineRun is the URL of the pipeline run in which the decision was made
+	PipelineRun string `json:"pipelineRun,omitempty"`
+}
+
+// ValidateGateName returns an error if the name cannot be used as the name of a gate; names must be valid names of annotation keys, such as 'canary-gate'.
+func ValidateGateName(name string) error {
+	if errs := validation.IsQualifiedName(GateAnnotationPrefix + name); len(errs) > 0 {
+		return errors.New("invalid gate name: " + name + ": " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// GetGateDecisions returns the gate decisions recorded on the experiment, ordered by time and gate name. Annotations with malformed values are ignored.
+func (e *Experiment) GetGateDecisions() []GateDecision {
+	var decisions []GateDecision
+	for k, v := range e.Annotations {
+		if !strings.HasPrefix(k, GateAnnotationPrefix) {
+			continue
+		}
+		d := GateDecision{}
+		if err := json.Unmarshal([]byte(v), &d); err != nil {
+			log.Warnf("ignoring malformed gate annotation %s: %v", k, err)
+			continue
+		}
+		d.Gate = strings.TrimPrefix(k, GateAnnotationPrefix)
+		decisions = append(decisions, d)
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		if !decisions[i].Time.Equal(&decisions[j].Time) {
+			return decisions[i].Time.Before(&decisions[j].Time)
+		}
+		return decisions[i].Gate < decisions[j].Gate
+	})
+	return decisions
+}
+
+// AnnotateGateDecision records the gate decision on the experiment with the given namespace and name using a merge patch, replacing any earlier decision of the same gate.
+func AnnotateGateDecision(ctx context.Context, namespace string, name string, d GateDecision) error {
+	if err := ValidateGateName(d.Gate); err != nil {
+		return &Error{Kind: KindUsage, Err: err}
+	}
+	value, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{GateAnnotationPrefix + d.Gate: string(value)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return PatchExperiment(ctx, namespace, name, patch)
+}
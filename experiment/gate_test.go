@@ -0,0 +1,74 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseGateResult(t *testing.T) {
+	r, err := ParseGateResult("passed")
+	assert.NoError(t, err)
+	assert.Equal(t, GatePassed, r)
+	_, err = ParseGateResult("ok")
+	assert.EqualError(t, err, "invalid gate result: ok; expected one of passed, failed")
+}
+
+func TestValidateGateName(t *testing.T) {
+	assert.NoError(t, ValidateGateName("canary-gate"))
+	assert.Error(t, ValidateGateName(""))
+	assert.Error(t, ValidateGateName("canary gate"))
+	assert.Error(t, ValidateGateName("canary/gate"))
+}
+
+func TestGetGateDecisions(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.Empty(t, exp.GetGateDecisions())
+
+	exp.Annotations = map[string]string{
+		GateAnnotationPrefix + "smoke":  `{"result":"failed","time":"2021-06-01T13:00:00Z"}`,
+		GateAnnotationPrefix + "canary": `{"result":"passed","time":"2021-06-01T12:00:00Z","pipelineRun":"https://ci.example.com/runs/42"}`,
+		GateAnnotationPrefix + "broken": `passed`,
+		"app":                           "sklearn-iris",
+	}
+	decisions := exp.GetGateDecisions()
+	assert.Len(t, decisions, 2)
+	assert.Equal(t, "canary", decisions[0].Gate)
+	assert.Equal(t, GatePassed, decisions[0].Result)
+	assert.Equal(t, "https://ci.example.com/runs/42", decisions[0].PipelineRun)
+	assert.Equal(t, "smoke", decisions[1].Gate)
+	assert.Equal(t, GateFailed, decisions[1].Result)
+}
+
+func TestAnnotateGateDecision(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	exp.ResourceVersion = ""
+	exp.Annotations = map[string]string{"app": "sklearn-iris"}
+	rc := testutil.WithFakeObjects(t, &GetClient, nil, &exp.Experiment)
+
+	ctx := context.Background()
+	decided := metav1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, AnnotateGateDecision(ctx, exp.Namespace, exp.Name, GateDecision{Gate: "canary", Result: GateFailed, Time: decided}))
+	assert.NoError(t, AnnotateGateDecision(ctx, exp.Namespace, exp.Name, GateDecision{Gate: "canary", Result: GatePassed, Time: decided, PipelineRun: "https://ci.example.com/runs/42"}))
+
+	updated := &Experiment{}
+	assert.NoError(t, rc.Get(ctx, client.ObjectKey{Namespace: exp.Namespace, Name: exp.Name}, &updated.Experiment))
+	assert.Equal(t, "sklearn-iris", updated.Annotations["app"])
+	assert.Equal(t, `{"result":"passed","time":"2021-06-01T12:00:00Z","pipelineRun":"https://ci.example.com/runs/42"}`, updated.Annotations[GateAnnotationPrefix+"canary"])
+	decisions := updated.GetGateDecisions()
+	assert.Len(t, decisions, 1)
+	assert.Equal(t, GatePassed, decisions[0].Result)
+	assert.True(t, decisions[0].Time.Equal(&decided))
+
+	err = AnnotateGateDecision(ctx, exp.Namespace, "missing", GateDecision{Gate: "canary", Result: GatePassed, Time: decided})
+	assert.Equal(t, KindNotFound, KindOf(err))
+	err = AnnotateGateDecision(ctx, exp.Namespace, exp.Name, GateDecision{Gate: "canary gate", Result: GatePassed, Time: decided})
+	assert.Equal(t, KindUsage, KindOf(err))
+}
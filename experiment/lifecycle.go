@@ -0,0 +1,113 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The iter8 controller has no explicit means of terminating, pausing, or resuming an experiment. Instead, it finishes an experiment once the number of completed iterations reaches spec.duration.iterationsPerLoop * spec.duration.maxLoops, and starts an iteration spec.duration.intervalSeconds after the previous one.
+// Experiments are therefore terminated by reducing the number of iterations to those already completed, and paused by increasing the interval between iterations to the largest possible value.
+// These patches are best-effort workarounds rather than requests the controller recognizes: the controller reports no paused state, and an iteration in progress completes regardless of them.
+
+// PausedIntervalAnnotation is the annotation recording the interval between iterations of a paused experiment, in seconds, which is restored when the experiment is resumed.
+const PausedIntervalAnnotation = "iter8ctl.iter8.tools/paused-interval-seconds"
+
+// pausedIntervalSeconds is the interval between iterations of paused experiments.
+const pausedIntervalSeconds = math.MaxInt32
+
+// Paused indicates if the experiment has been paused using PausePatch.
+func (e *Experiment) Paused() bool {
+	_, ok := e.Annotations[PausedIntervalAnnotation]
+	return ok
+}
+
+// TerminatePatch returns the merge patch which causes the iter8 controller to finish the experiment after its current iteration, as though it had completed every iteration; the controller reports the experiment as finishing once it does.
+// The experiment is finished in the usual manner; for example, its finish handler promotes the version recommended for promotion.
+// At least one iteration is run if none has completed, since the controller requires a positive number of iterations.
+func (e *Experiment) TerminatePatch() ([]byte, error) {
+	if e.Completed() {
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s has already completed", e.Namespace, e.Name))
+	}
+	iterations := e.Status.GetCompletedIterations()
+	if iterations < 1 {
+		iterations = 1
+	}
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"duration": map[string]interface{}{
+				"iterationsPerLoop": iterations,
+				"maxLoops":          1,
+			},
+		},
+	})
+}
+
+// PausePatch returns the merge patch which prevents the iter8 controller from starting further iterations of the experiment, until the experiment is resumed using ResumePatch.
+// The pause is not observable in the status of the experiment; at most, its completed iterations stop advancing.
+// The current interval between iterations is recorded in the PausedIntervalAnnotation annotation.
+// Experiments cannot be paused before their first iteration completes, since the controller starts the first iteration regardless of the interval.
+func (e *Experiment) PausePatch() ([]byte, error) {
+	switch {
+	case e.Completed():
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s has already completed", e.Namespace, e.Name))
+	case e.Paused():
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s is already paused", e.Namespace, e.Name))
+	case !e.Started():
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s cannot be paused before its first iteration completes", e.Namespace, e.Name))
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				PausedIntervalAnnotation: strconv.Itoa(int(e.Spec.GetIntervalSeconds())),
+			},
+		},
+		"spec": map[string]interface{}{
+			"duration": map[string]interface{}{
+				"intervalSeconds": pausedIntervalSeconds,
+			},
+		},
+	})
+}
+
+// ResumePatch returns the merge patch which restores the interval between iterations of an experiment paused using PausePatch, and removes the PausedIntervalAnnotation annotation.
+// The controller starts the next iteration once the restored interval has passed since the previous iteration; its completed iterations then advance.
+func (e *Experiment) ResumePatch() ([]byte, error) {
+	if !e.Paused() {
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s is not paused", e.Namespace, e.Name))
+	}
+	interval, err := strconv.Atoi(e.Annotations[PausedIntervalAnnotation])
+	if err != nil || interval < 1 {
+		return nil, NewError(KindUsage, fmt.Sprintf("invalid %s annotation on experiment %s/%s: %s", PausedIntervalAnnotation, e.Namespace, e.Name, e.Annotations[PausedIntervalAnnotation]))
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				// null removes the annotation
+				PausedIntervalAnnotation: nil,
+			},
+		},
+		"spec": map[string]interface{}{
+			"duration": map[string]interface{}{
+				"intervalSeconds": interval,
+			},
+		},
+	})
+}
+
+// PatchExperiment applies the JSON merge patch to the experiment with the given namespace and name.
+func PatchExperiment(ctx context.Context, namespace string, name string, patch []byte) error {
+	rc, err := GetClient()
+	if err != nil {
+		return clusterError(err)
+	}
+	exp := &v2alpha2.Experiment{}
+	exp.Namespace, exp.Name = namespace, name
+	return clusterError(rc.Patch(ctx, exp, client.RawPatch(types.MergePatchType, patch)))
+}
@@ -0,0 +1,67 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTerminatePatch(t *testing.T) {
+	exp, err := getExp("experiment5")
+	assert.NoError(t, err)
+	patch, err := exp.TerminatePatch()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"duration":{"iterationsPerLoop":7,"maxLoops":1}}}`, string(patch))
+
+	exp.Status.CompletedIterations = nil
+	patch, err = exp.TerminatePatch()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"duration":{"iterationsPerLoop":1,"maxLoops":1}}}`, string(patch))
+
+	exp, err = getExp("experiment8")
+	assert.NoError(t, err)
+	_, err = exp.TerminatePatch()
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 has already completed")
+	assert.Equal(t, KindUsage, KindOf(err))
+}
+
+func TestPauseAndResume(t *testing.T) {
+	exp, err := getExp("experiment5")
+	assert.NoError(t, err)
+	exp.ResourceVersion = ""
+	rc := testutil.WithFakeObjects(t, &GetClient, &GetWatchClient, &exp.Experiment)
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: exp.Namespace, Name: exp.Name}
+
+	_, err = exp.ResumePatch()
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 is not paused")
+	patch, err := exp.PausePatch()
+	assert.NoError(t, err)
+	assert.NoError(t, PatchExperiment(ctx, exp.Namespace, exp.Name, patch))
+
+	paused := &Experiment{}
+	assert.NoError(t, rc.Get(ctx, key, &paused.Experiment))
+	assert.True(t, paused.Paused())
+	assert.Equal(t, "15", paused.Annotations[PausedIntervalAnnotation])
+	assert.Equal(t, int32(pausedIntervalSeconds), paused.Spec.GetIntervalSeconds())
+	_, err = paused.PausePatch()
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 is already paused")
+
+	patch, err = paused.ResumePatch()
+	assert.NoError(t, err)
+	assert.NoError(t, PatchExperiment(ctx, exp.Namespace, exp.Name, patch))
+	resumed := &Experiment{}
+	assert.NoError(t, rc.Get(ctx, key, &resumed.Experiment))
+	assert.False(t, resumed.Paused())
+	assert.Equal(t, int32(15), resumed.Spec.GetIntervalSeconds())
+
+	exp.Status.CompletedIterations = nil
+	_, err = exp.PausePatch()
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 cannot be paused before its first iteration completes")
+	exp.Annotations = map[string]string{PausedIntervalAnnotation: "soon"}
+	_, err = exp.ResumePatch()
+	assert.Equal(t, KindUsage, KindOf(err))
+}
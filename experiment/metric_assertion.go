@@ -0,0 +1,199 @@
+package experiment
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/inf.v0"
+)
+
+// ComparisonOp is a comparison operator used in metric assertions.
+type ComparisonOp string
+
+const (
+	// OpLessThan requires the metric value to be strictly below the threshold
+	OpLessThan ComparisonOp = "<"
+	// OpLessThanOrEqual requires the metric value to be at most the threshold
+	OpLessThanOrEqual ComparisonOp = "<="
+	// OpGreaterThan requires the metric value to be strictly above the threshold
+	OpGreaterThan ComparisonOp = ">"
+	// OpGreaterThanOrEqual requires the metric value to be at least the threshold
+	OpGreaterThanOrEqual ComparisonOp = ">="
+	// OpEqual requires the metric value to equal the threshold
+	OpEqual ComparisonOp = "=="
+	// OpNotEqual requires the metric value to differ from the threshold
+	OpNotEqual ComparisonOp = "!="
+)
+
+// holds reports whether cmp, the result of comparing a value with a threshold, satisfies the operator.
+func (op ComparisonOp) holds(cmp int) bool {
+	switch op {
+	case OpLessThan:
+		return cmp < 0
+	case OpLessThanOrEqual:
+		return cmp <= 0
+	case OpGreaterThan:
+		return cmp > 0
+	case OpGreaterThanOrEqual:
+		return cmp >= 0
+	case OpEqual:
+		return cmp == 0
+	case OpNotEqual:
+		return cmp != 0
+	}
+	return false
+}
+
+// MetricRef refers to the value of a metric for a version, written as metric[version].
+type MetricRef struct {
+	Metric  string
+	Version string
+}
+
+// String returns the metric[version] representation of the reference.
+func (r MetricRef) String() string {
+	return r.Metric + "[" + r.Version + "]"
+}
+
+// MetricAssertion is a parsed metric threshold assertion.
+// If Relative is nil, the assertion compares Left against the absolute threshold Value.
+// Otherwise, it compares Left against Value times the value of Relative.
+type MetricAssertion struct {
+	Left     MetricRef
+	Op       ComparisonOp
+	Value    *inf.Dec
+	Relative *MetricRef
+}
+
+// String returns the assertion in the syntax accepted by ParseMetricAssertion.
+func (m *MetricAssertion) String() string {
+	r := m.Left.String() + " " + string(m.Op) + " "
+	if m.Relative == nil {
+		return r + m.Value.String()
+	}
+	if m.Value.Cmp(inf.NewDec(1, 0)) != 0 {
+		r += m.Value.String() + " * "
+	}
+	return r + m.Relative.String()
+}
+
+const metricAssertionSyntax = "expected '<metric>[<version>] <op> <number>' or '<metric>[<version>] <op> [<number> *] <metric>[<version>]', where <op> is one of <, <=, >, >=, ==, !="
+
+var metricAssertionRegexp = regexp.MustCompile(`^\s*([^\s\[\]]+)\[([^\s\[\]]+)\]\s*(<=|>=|==|!=|<|>)\s*(.*?)\s*$`)
+var relativeRHSRegexp = regexp.MustCompile(`^(?:([^\s*]+)\s*\*\s*)?([^\s\[\]]+)\[([^\s\[\]]+)\]$`)
+
+// ParseMetricAssertion parses a metric threshold assertion such as 'iter8-istio/error-rate[B] < 0.005' or
+// 'iter8-istio/mean-latency[canary] <= 1.1 * iter8-istio/mean-latency[default]'.
+func ParseMetricAssertion(s string) (*MetricAssertion, error) {
+	match := metricAssertionRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid metric assertion %q: %s", s, metricAssertionSyntax)
+	}
+	m := &MetricAssertion{
+		Left: MetricRef{Metric: match[1], Version: match[2]},
+		Op:   ComparisonOp(match[3]),
+	}
+	rhs := match[4]
+	if rhs == "" {
+		return nil, fmt.Errorf("invalid metric assertion %q: missing right hand side; %s", s, metricAssertionSyntax)
+	}
+	if v, ok := new(inf.Dec).SetString(rhs); ok {
+		m.Value = v
+		return m, nil
+	}
+	rel := relativeRHSRegexp.FindStringSubmatch(rhs)
+	if rel == nil {
+		return nil, fmt.Errorf("invalid metric assertion %q: cannot parse %q as a number or a metric reference; %s", s, rhs, metricAssertionSyntax)
+	}
+	m.Value = inf.NewDec(1, 0)
+	if rel[1] != "" {
+		v, ok := new(inf.Dec).SetString(rel[1])
+		if !ok {
+			return nil, fmt.Errorf("invalid metric assertion %q: cannot parse factor %q as a number", s, rel[1])
+		}
+		m.Value = v
+	}
+	m.Relative = &MetricRef{Metric: rel[2], Version: rel[3]}
+	return m, nil
+}
+
+// GetMetricNames returns the names of metrics known to the experiment, from its status.metrics section and its aggregated metrics.
+func (e *Experiment) GetMetricNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, mi := range e.Status.Metrics {
+		add(mi.Name)
+	}
+	if a := e.Status.Analysis; a != nil && a.AggregatedMetrics != nil {
+		var aggregated []string
+		for name := range a.AggregatedMetrics.Data {
+			aggregated = append(aggregated, name)
+		}
+		sort.Strings(aggregated)
+		for _, name := range aggregated {
+			add(name)
+		}
+	}
+	return names
+}
+
+// resolveMetricRef returns the unrounded value referred to by ref, or an error explaining why it is not available.
+func (e *Experiment) resolveMetricRef(ref MetricRef) (*inf.Dec, error) {
+	names := e.GetMetricNames()
+	if !containsString(names, ref.Metric) {
+		return nil, fmt.Errorf("unknown metric %s; metrics in experiment: [%s]", ref.Metric, strings.Join(names, ", "))
+	}
+	versions := e.GetVersions()
+	if !containsString(versions, ref.Version) {
+		return nil, fmt.Errorf("unknown version %s; versions in experiment: [%s]", ref.Version, strings.Join(versions, ", "))
+	}
+	val := e.getMetricValue(ref.Metric, ref.Version)
+	if val == nil {
+		return nil, fmt.Errorf("value of %s is unavailable", ref)
+	}
+	return val, nil
+}
+
+// AssertMetric verifies a metric threshold assertion for the experiment.
+func (e *Experiment) AssertMetric(m *MetricAssertion) ConditionResult {
+	res := ConditionResult{Name: m.String()}
+	left, err := e.resolveMetricRef(m.Left)
+	if err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+	threshold := m.Value
+	observed := fmt.Sprintf("observed %s", left)
+	if m.Relative != nil {
+		right, err := e.resolveMetricRef(*m.Relative)
+		if err != nil {
+			res.Reason = err.Error()
+			return res
+		}
+		threshold = new(inf.Dec).Mul(m.Value, right)
+		observed += fmt.Sprintf(", %s is %s", m.Relative, right)
+	}
+	res.Satisfied = m.Op.holds(left.Cmp(threshold))
+	if !res.Satisfied {
+		res.Reason = fmt.Sprintf("%s not satisfied: %s", m, observed)
+	}
+	return res
+}
+
+// containsString indicates if the slice contains the string.
+func containsString(slice []string, s string) bool {
+	for _, elem := range slice {
+		if elem == s {
+			return true
+		}
+	}
+	return false
+}
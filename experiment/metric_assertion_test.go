@@ -0,0 +1,83 @@
+package experiment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestParseMetricAssertion(t *testing.T) {
+	m, err := ParseMetricAssertion("iter8-istio/error-rate[B] < 0.005")
+	assert.NoError(t, err)
+	assert.Equal(t, MetricRef{Metric: "iter8-istio/error-rate", Version: "B"}, m.Left)
+	assert.Equal(t, OpLessThan, m.Op)
+	assert.Equal(t, "0.005", m.Value.String())
+	assert.Nil(t, m.Relative)
+	assert.Equal(t, "iter8-istio/error-rate[B] < 0.005", m.String())
+
+	m, err = ParseMetricAssertion(" mean-latency[canary]<=1.1*mean-latency[default] ")
+	assert.NoError(t, err)
+	assert.Equal(t, OpLessThanOrEqual, m.Op)
+	assert.Equal(t, "1.1", m.Value.String())
+	assert.Equal(t, &MetricRef{Metric: "mean-latency", Version: "default"}, m.Relative)
+	assert.Equal(t, "mean-latency[canary] <= 1.1 * mean-latency[default]", m.String())
+
+	m, err = ParseMetricAssertion("mean-latency[canary] >= mean-latency[default]")
+	assert.NoError(t, err)
+	assert.Equal(t, "mean-latency[canary] >= mean-latency[default]", m.String())
+}
+
+func TestParseMetricAssertionErrors(t *testing.T) {
+	for _, s := range []string{
+		"error-rate < 0.005",
+		"error-rate[B] 0.005",
+		"error-rate[B] <",
+		"error-rate[B] < abc",
+		"error-rate[B] < x * error-rate[A]",
+	} {
+		_, err := ParseMetricAssertion(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAssertMetric(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		assertion string
+		satisfied bool
+		reason    string
+	}{
+		{"error-rate[canary] < 0.005", true, ""},
+		{"mean-latency[canary] <= 1.1 * mean-latency[default]", true, ""},
+		{"mean-latency[canary] < mean-latency[default]", false, "mean-latency[canary] < mean-latency[default] not satisfied: observed 229.001070304, mean-latency[default] is 228.419047620"},
+		{"request-count[canary] > 100", false, "request-count[canary] > 100 not satisfied: observed 57.714400001"},
+		{"fake[canary] > 100", false, "unknown metric fake; metrics in experiment: [95th-percentile-tail-latency, mean-latency, error-rate, request-count]"},
+		{"error-rate[perfect] > 100", false, "unknown version perfect; versions in experiment: [default, canary]"},
+		{"error-rate[canary] < 2 * error-rate[perfect]", false, "unknown version perfect; versions in experiment: [default, canary]"},
+	} {
+		m, err := ParseMetricAssertion(tc.assertion)
+		assert.NoError(t, err)
+		res := exp.AssertMetric(m)
+		assert.Equal(t, tc.satisfied, res.Satisfied, tc.assertion)
+		assert.Equal(t, tc.reason, res.Reason, tc.assertion)
+	}
+}
+
+func TestAssertMetricUnavailable(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	delete(exp.Status.Analysis.AggregatedMetrics.Data["error-rate"].Data, "canary")
+
+	m, _ := ParseMetricAssertion("error-rate[canary] < 0.005")
+	res := exp.AssertMetric(m)
+	assert.False(t, res.Satisfied)
+	assert.Equal(t, "value of error-rate[canary] is unavailable", res.Reason)
+
+	exp.Status.Analysis = nil
+	res = exp.AssertMetric(m)
+	assert.False(t, res.Satisfied)
+}
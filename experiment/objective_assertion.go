@@ -0,0 +1,115 @@
+package experiment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AssertObjective verifies that the given version satisfies the objective with the given index.
+// If the objective is not satisfied, or its assessment is unavailable, the reason includes the observed value of the objective's metric and the objective's limits.
+func (e *Experiment) AssertObjective(objectiveIndex int, version string) ConditionResult {
+	if e.Spec.Criteria == nil || objectiveIndex < 0 || objectiveIndex >= len(e.Spec.Criteria.Objectives) {
+		return ConditionResult{
+			Name:   fmt.Sprintf("objective %v [%s]", objectiveIndex, version),
+			Reason: fmt.Sprintf("objective %v not found in experiment", objectiveIndex),
+		}
+	}
+	objective := e.Spec.Criteria.Objectives[objectiveIndex]
+	obj := StringifyObjective(objective)
+	res := ConditionResult{Name: fmt.Sprintf("%s [%s]", obj, version)}
+	observed := e.GetMetricStr(objective.Metric, version)
+	switch e.GetSatisfyStr(objectiveIndex, version) {
+	case "true":
+		res.Satisfied = true
+	case "false":
+		res.Reason = fmt.Sprintf("version %s does not satisfy objective %s; observed value of %s is %s", version, obj, objective.Metric, observed)
+	default:
+		res.Reason = fmt.Sprintf("assessment of objective %s is unavailable for version %s; observed value of %s is %s", obj, version, objective.Metric, observed)
+	}
+	return res
+}
+
+// AssertObjectivesSatisfied verifies that the given version satisfies every objective of the experiment.
+// The version may also be specified by its role; i.e., baseline or candidate, in which case every version with that role must satisfy every objective.
+func (e *Experiment) AssertObjectivesSatisfied(versionOrRole string) ConditionResult {
+	res := ConditionResult{Name: "objectives satisfied by " + versionOrRole}
+	versions, err := e.ResolveVersions(versionOrRole)
+	if err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+	if e.Spec.Criteria == nil || len(e.Spec.Criteria.Objectives) == 0 {
+		res.Reason = "experiment has no objectives"
+		return res
+	}
+	var reasons []string
+	for _, version := range versions {
+		for i := range e.Spec.Criteria.Objectives {
+			if r := e.AssertObjective(i, version); !r.Satisfied {
+				reasons = append(reasons, r.Reason)
+			}
+		}
+	}
+	res.Satisfied = len(reasons) == 0
+	res.Reason = strings.Join(reasons, "; ")
+	return res
+}
+
+// ObjectiveRef refers to the objectives on a metric for a version, written as metric@version.
+type ObjectiveRef struct {
+	Metric  string
+	Version string
+}
+
+// String returns the metric@version representation of the reference.
+func (r ObjectiveRef) String() string {
+	return r.Metric + "@" + r.Version
+}
+
+// ParseObjectiveRef parses an objective reference such as 'iter8-istio/mean-latency@canary'.
+func ParseObjectiveRef(s string) (ObjectiveRef, error) {
+	i := strings.LastIndex(s, "@")
+	if i <= 0 || i == len(s)-1 {
+		return ObjectiveRef{}, fmt.Errorf("invalid objective %q: expected '<metric>@<version>'", s)
+	}
+	return ObjectiveRef{
+		Metric:  strings.TrimSpace(s[:i]),
+		Version: strings.TrimSpace(s[i+1:]),
+	}, nil
+}
+
+// AssertObjectiveRef verifies that the version satisfies every objective on the metric referred to by ref.
+// The version may also be specified by its role; i.e., baseline or candidate.
+func (e *Experiment) AssertObjectiveRef(ref ObjectiveRef) ConditionResult {
+	res := ConditionResult{Name: "objective " + ref.String()}
+	versions, err := e.ResolveVersions(ref.Version)
+	if err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+	var indices []int
+	var metrics []string
+	if e.Spec.Criteria != nil {
+		for i, objective := range e.Spec.Criteria.Objectives {
+			metrics = append(metrics, objective.Metric)
+			if objective.Metric == ref.Metric {
+				indices = append(indices, i)
+			}
+		}
+	}
+	if len(indices) == 0 {
+		res.Reason = fmt.Sprintf("no objective on metric %s; metrics with objectives in experiment: [%s]", ref.Metric, strings.Join(metrics, ", "))
+		return res
+	}
+	var reasons []string
+	for _, version := range versions {
+		for _, i := range indices {
+			if r := e.AssertObjective(i, version); !r.Satisfied {
+				reasons = append(reasons, r.Reason)
+			}
+		}
+	}
+	res.Satisfied = len(reasons) == 0
+	res.Reason = strings.Join(reasons, "; ")
+	return res
+}
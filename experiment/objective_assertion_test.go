@@ -0,0 +1,63 @@
+package experiment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* Tests */
+
+func TestAssertObjective(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertObjective(0, "canary").Satisfied)
+	assert.Equal(t, "mean-latency <= 1000.000 [canary]", exp.AssertObjective(0, "canary").Name)
+	assert.Equal(t, "assessment of objective error-rate <= 0.010 is unavailable for version perfect; observed value of error-rate is unavailable", exp.AssertObjective(1, "perfect").Reason)
+	assert.Equal(t, "objective 2 not found in experiment", exp.AssertObjective(2, "canary").Reason)
+
+	exp.Status.Analysis.VersionAssessments.Data["canary"][0] = false
+	assert.Equal(t, "version canary does not satisfy objective mean-latency <= 1000.000; observed value of mean-latency is 229.002", exp.AssertObjective(0, "canary").Reason)
+}
+
+func TestAssertObjectivesSatisfied(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertObjectivesSatisfied("canary").Satisfied)
+	assert.True(t, exp.AssertObjectivesSatisfied(CandidateRole).Satisfied)
+	assert.Equal(t, "unknown version perfect; versions in experiment: [default, canary]", exp.AssertObjectivesSatisfied("perfect").Reason)
+
+	exp.Status.Analysis.VersionAssessments.Data["default"][1] = false
+	res := exp.AssertObjectivesSatisfied(BaselineRole)
+	assert.False(t, res.Satisfied)
+	assert.Equal(t, "version default does not satisfy objective error-rate <= 0.010; observed value of error-rate is 0.000", res.Reason)
+
+	exp.Spec.Criteria.Objectives = nil
+	assert.Equal(t, "experiment has no objectives", exp.AssertObjectivesSatisfied("canary").Reason)
+}
+
+func TestParseObjectiveRef(t *testing.T) {
+	ref, err := ParseObjectiveRef("iter8-istio/mean-latency@B")
+	assert.NoError(t, err)
+	assert.Equal(t, ObjectiveRef{Metric: "iter8-istio/mean-latency", Version: "B"}, ref)
+	assert.Equal(t, "iter8-istio/mean-latency@B", ref.String())
+
+	for _, s := range []string{"mean-latency", "@B", "mean-latency@"} {
+		_, err := ParseObjectiveRef(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAssertObjectiveRef(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	assert.True(t, exp.AssertObjectiveRef(ObjectiveRef{Metric: "mean-latency", Version: "canary"}).Satisfied)
+	assert.Equal(t, "no objective on metric request-count; metrics with objectives in experiment: [mean-latency, error-rate]",
+		exp.AssertObjectiveRef(ObjectiveRef{Metric: "request-count", Version: "canary"}).Reason)
+
+	delete(exp.Status.Analysis.VersionAssessments.Data, "canary")
+	res := exp.AssertObjectiveRef(ObjectiveRef{Metric: "error-rate", Version: "canary"})
+	assert.False(t, res.Satisfied)
+	assert.Equal(t, "objective error-rate@canary", res.Name)
+	assert.Equal(t, "assessment of objective error-rate <= 0.010 is unavailable for version canary; observed value of error-rate is 0.000", res.Reason)
+}
@@ -0,0 +1,203 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The version recommended for promotion is part of the status of an experiment, which is maintained by the iter8 controller; it cannot be overridden.
+// Versions are therefore promoted in the same manner in which the controller redistributes traffic: by patching the field of the weightObjRef of every version with its weight.
+
+// WeightPatch is a JSON patch of a K8s object holding the weights of one or more versions of an experiment.
+type WeightPatch struct {
+	// Object is the patched object; its FieldPath is empty
+	Object corev1.ObjectReference
+	// Patch is the JSON patch of the object
+	Patch []byte
+}
+
+// jsonPatchOp is an operation of a JSON patch replacing or testing a value.
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// fieldPathToJSONPointer returns the JSON pointer referring to the same field as the field path of a weightObjRef; for example, .spec.http[0].route[1].weight is /spec/http/0/route/1/weight.
+// The leading dot of the field path is optional. Keys in brackets may contain dots, and may be quoted, as in .metadata.annotations['example.com/weight'].
+// ~ and / in keys are escaped as ~0 and ~1.
+func fieldPathToJSONPointer(fieldPath string) string {
+	var keys []string
+	for rest := fieldPath; rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				// unterminated brackets extend to the end of the field path
+				end = len(rest)
+				rest += "]"
+			}
+			keys = append(keys, strings.Trim(rest[1:end], `'"`))
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			keys = append(keys, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString("/")
+		b.WriteString(jsonPointerEscaper.Replace(key))
+	}
+	return b.String()
+}
+
+// jsonPointerEscaper escapes keys in JSON pointers.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonPointerUnescaper unescapes keys in JSON pointers.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// jsonPointerValue returns the value referred to by the JSON pointer in the content of an unstructured object, and whether it exists.
+func jsonPointerValue(content interface{}, pointer string) (interface{}, bool) {
+	for _, key := range strings.Split(pointer, "/")[1:] {
+		switch c := content.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if content, ok = c[jsonPointerUnescaper.Replace(key)]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			content = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return content, true
+}
+
+// PromotePatches returns the patches which send all traffic to the given version of the experiment, and no traffic to its other versions, in the order in which the versions hold their weights.
+// The version may also be specified by its role; i.e., baseline, or candidate if the experiment has a single candidate.
+// Versions without a weightObjRef field path are not patched; an error is returned if no version has one.
+// Experiments must have completed, since the controller redistributes the traffic of running experiments.
+func (e *Experiment) PromotePatches(versionOrRole string) ([]WeightPatch, error) {
+	if !e.Completed() {
+		return nil, NewError(KindUsage, fmt.Sprintf("experiment %s/%s has not completed", e.Namespace, e.Name))
+	}
+	versions, err := e.ResolveVersions(versionOrRole)
+	if err != nil {
+		return nil, &Error{Kind: KindUsage, Err: err}
+	}
+	if len(versions) > 1 {
+		return nil, NewError(KindUsage, fmt.Sprintf("%s refers to more than one version: [%s]", versionOrRole, strings.Join(versions, ", ")))
+	}
+
+	var objects []corev1.ObjectReference
+	ops := map[corev1.ObjectReference][]jsonPatchOp{}
+	details := append([]v2alpha2.VersionDetail{e.Spec.VersionInfo.Baseline}, e.Spec.VersionInfo.Candidates...)
+	for _, v := range details {
+		if v.WeightObjRef == nil || v.WeightObjRef.FieldPath == "" {
+			continue
+		}
+		// key is the object without the field path
+		key := corev1.ObjectReference{
+			APIVersion: v.WeightObjRef.APIVersion,
+			Kind:       v.WeightObjRef.Kind,
+			Namespace:  v.WeightObjRef.Namespace,
+			Name:       v.WeightObjRef.Name,
+		}
+		if key.Namespace == "" {
+			key.Namespace = e.Namespace
+		}
+		if _, ok := ops[key]; !ok {
+			objects = append(objects, key)
+		}
+		// weights are replaced rather than added, since adding to an array inserts an element instead of replacing it
+		op := jsonPatchOp{Op: "replace", Path: fieldPathToJSONPointer(v.WeightObjRef.FieldPath), Value: int32(0)}
+		if v.Name == versions[0] {
+			op.Value = int32(100)
+		}
+		ops[key] = append(ops[key], op)
+	}
+	if len(objects) == 0 {
+		return nil, NewError(KindUsage, fmt.Sprintf("no version of experiment %s/%s has a weightObjRef with a field path", e.Namespace, e.Name))
+	}
+
+	patches := make([]WeightPatch, len(objects))
+	for i, obj := range objects {
+		patch, err := json.Marshal(ops[obj])
+		if err != nil {
+			return nil, err
+		}
+		patches[i] = WeightPatch{Object: obj, Patch: patch}
+	}
+	return patches, nil
+}
+
+// object returns the patched object, holding only its type, namespace, and name.
+func (p WeightPatch) object() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(p.Object.APIVersion, p.Object.Kind))
+	obj.SetNamespace(p.Object.Namespace)
+	obj.SetName(p.Object.Name)
+	return obj
+}
+
+// GuardWeightPatch returns the patch with a test of the current value of every patched field preceding its replacement, so that the patch is not applied if a weight changes in the meantime.
+// Since the object is read from the cluster, but not modified, guarding every patch before applying any validates them all; an error is returned if the object, or a patched field, does not exist.
+func GuardWeightPatch(ctx context.Context, p WeightPatch) (WeightPatch, error) {
+	rc, err := GetClient()
+	if err != nil {
+		return p, clusterError(err)
+	}
+	obj := p.object()
+	if err := rc.Get(ctx, client.ObjectKey{Namespace: p.Object.Namespace, Name: p.Object.Name}, obj); err != nil {
+		return p, clusterError(err)
+	}
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(p.Patch, &ops); err != nil {
+		return p, err
+	}
+	var guarded []jsonPatchOp
+	for _, op := range ops {
+		current, ok := jsonPointerValue(obj.Object, op.Path)
+		if !ok {
+			return p, NewError(KindNotFound, fmt.Sprintf("%s %s/%s has no field %s", p.Object.Kind, p.Object.Namespace, p.Object.Name, op.Path))
+		}
+		guarded = append(guarded, jsonPatchOp{Op: "test", Path: op.Path, Value: current}, op)
+	}
+	patch, err := json.Marshal(guarded)
+	if err != nil {
+		return p, err
+	}
+	return WeightPatch{Object: p.Object, Patch: patch}, nil
+}
+
+// ApplyWeightPatch applies the JSON patch to its object.
+func ApplyWeightPatch(ctx context.Context, p WeightPatch) error {
+	rc, err := GetClient()
+	if err != nil {
+		return clusterError(err)
+	}
+	return clusterError(rc.Patch(ctx, p.object(), client.RawPatch(types.JSONPatchType, p.Patch)))
+}
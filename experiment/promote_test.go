@@ -0,0 +1,126 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFieldPathToJSONPointer(t *testing.T) {
+	assert.Equal(t, "/spec/http/0/route/1/weight", fieldPathToJSONPointer(".spec.http[0].route[1].weight"))
+	assert.Equal(t, "/spec/traffic/0/percent", fieldPathToJSONPointer(".spec.traffic[0].percent"))
+	assert.Equal(t, "/spec/weights/1", fieldPathToJSONPointer(".spec.weights[1]"))
+	assert.Equal(t, "/spec/replicas", fieldPathToJSONPointer("spec.replicas"))
+	assert.Equal(t, "/metadata/annotations/example.com~1weight", fieldPathToJSONPointer(".metadata.annotations[example.com/weight]"))
+	assert.Equal(t, "/metadata/annotations/example.com~1weight", fieldPathToJSONPointer(".metadata.annotations['example.com/weight']"))
+	assert.Equal(t, "/spec/a~0b/c~1d", fieldPathToJSONPointer(".spec.a~b.c/d"))
+	assert.Equal(t, "/spec/weights/10", fieldPathToJSONPointer(".spec.weights[10"))
+}
+
+func TestPromotePatches(t *testing.T) {
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	patches, err := exp.PromotePatches("B")
+	assert.NoError(t, err)
+	assert.Len(t, patches, 1)
+	assert.Equal(t, "VirtualService", patches[0].Object.Kind)
+	assert.Equal(t, "bookinfo-iter8", patches[0].Object.Namespace)
+	assert.Equal(t, "bookinfo", patches[0].Object.Name)
+	assert.Empty(t, patches[0].Object.FieldPath)
+	assert.JSONEq(t, `[{"op":"replace","path":"/spec/http/0/route/0/weight","value":0},{"op":"replace","path":"/spec/http/0/route/1/weight","value":100}]`, string(patches[0].Patch))
+
+	patches, err = exp.PromotePatches(BaselineRole)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"replace","path":"/spec/http/0/route/0/weight","value":100},{"op":"replace","path":"/spec/http/0/route/1/weight","value":0}]`, string(patches[0].Patch))
+
+	_, err = exp.PromotePatches("C")
+	assert.EqualError(t, err, "unknown version C; versions in experiment: [A, B]")
+	assert.Equal(t, KindUsage, KindOf(err))
+
+	// versions without a weightObjRef are not patched
+	exp.Spec.VersionInfo.Baseline.WeightObjRef.Namespace = ""
+	exp.Spec.VersionInfo.Candidates[0].WeightObjRef = nil
+	patches, err = exp.PromotePatches("B")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", patches[0].Object.Namespace)
+	assert.JSONEq(t, `[{"op":"replace","path":"/spec/http/0/route/0/weight","value":0}]`, string(patches[0].Patch))
+	exp.Spec.VersionInfo.Baseline.WeightObjRef = nil
+	_, err = exp.PromotePatches("B")
+	assert.EqualError(t, err, "no version of experiment default/istio-quickstart has a weightObjRef with a field path")
+
+	exp, err = getExp("experiment5")
+	assert.NoError(t, err)
+	_, err = exp.PromotePatches(BaselineRole)
+	assert.EqualError(t, err, "experiment kfserving-test/sklearn-iris-experiment-1 has not completed")
+}
+
+func TestJSONPointerValue(t *testing.T) {
+	content := map[string]interface{}{"spec": map[string]interface{}{
+		"http":        []interface{}{map[string]interface{}{"weight": int64(40)}},
+		"example/a~b": "c",
+	}}
+	v, ok := jsonPointerValue(content, "/spec/http/0/weight")
+	assert.True(t, ok)
+	assert.Equal(t, int64(40), v)
+	v, ok = jsonPointerValue(content, "/spec/example~1a~0b")
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+	for _, pointer := range []string{"/spec/http/1/weight", "/spec/http/x/weight", "/spec/http/0/weight/0", "/status"} {
+		_, ok = jsonPointerValue(content, pointer)
+		assert.False(t, ok, pointer)
+	}
+}
+
+func TestApplyWeightPatch(t *testing.T) {
+	vs := &unstructured.Unstructured{}
+	vs.SetAPIVersion("networking.istio.io/v1beta1")
+	vs.SetKind("VirtualService")
+	vs.SetNamespace("bookinfo-iter8")
+	vs.SetName("bookinfo")
+	assert.NoError(t, unstructured.SetNestedSlice(vs.Object, []interface{}{map[string]interface{}{
+		"route": []interface{}{
+			map[string]interface{}{"weight": int64(40)},
+			map[string]interface{}{"weight": int64(60)},
+		},
+	}}, "spec", "http"))
+	rc := testutil.WithFakeObjects(t, &GetClient, nil, vs)
+
+	exp, err := getExp("experiment12")
+	assert.NoError(t, err)
+	patches, err := exp.PromotePatches("B")
+	assert.NoError(t, err)
+	guarded, err := GuardWeightPatch(context.Background(), patches[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"test","path":"/spec/http/0/route/0/weight","value":40},{"op":"replace","path":"/spec/http/0/route/0/weight","value":0},`+
+		`{"op":"test","path":"/spec/http/0/route/1/weight","value":60},{"op":"replace","path":"/spec/http/0/route/1/weight","value":100}]`, string(guarded.Patch))
+	assert.NoError(t, ApplyWeightPatch(context.Background(), guarded))
+
+	patched := &unstructured.Unstructured{}
+	patched.SetGroupVersionKind(vs.GroupVersionKind())
+	assert.NoError(t, rc.Get(context.Background(), client.ObjectKey{Namespace: "bookinfo-iter8", Name: "bookinfo"}, patched))
+	http, _, _ := unstructured.NestedSlice(patched.Object, "spec", "http")
+	routes := http[0].(map[string]interface{})["route"].([]interface{})
+	assert.Len(t, routes, 2)
+	assert.EqualValues(t, 0, routes[0].(map[string]interface{})["weight"])
+	assert.EqualValues(t, 100, routes[1].(map[string]interface{})["weight"])
+
+	// weights which changed since the patch was guarded are not replaced
+	assert.Error(t, ApplyWeightPatch(context.Background(), guarded))
+
+	missing := patches[0]
+	missing.Object.Name = "missing"
+	_, err = GuardWeightPatch(context.Background(), missing)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	assert.Equal(t, KindNotFound, KindOf(ApplyWeightPatch(context.Background(), missing)))
+
+	exp.Spec.VersionInfo.Candidates[0].WeightObjRef.FieldPath = ".spec.http[0].route[2].weight"
+	patches, err = exp.PromotePatches("B")
+	assert.NoError(t, err)
+	_, err = GuardWeightPatch(context.Background(), patches[0])
+	assert.EqualError(t, err, "VirtualService bookinfo-iter8/bookinfo has no field /spec/http/0/route/2/weight")
+	assert.Equal(t, KindNotFound, KindOf(err))
+}
@@ -0,0 +1,110 @@
+package experiment
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Snapshot is the analysis of an experiment at a point in time.
+// The status of an experiment contains only the most recent analysis; successive snapshots retain trends across iterations.
+type Snapshot struct {
+	// Time at which the snapshot was taken
+	Time      metav1.Time `json:"time"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	// Versions contains the baseline followed by candidates
+	Versions            []string                            `json:"versions,omitempty"`
+	Stage               *v2alpha2.ExperimentStageType       `json:"stage,omitempty"`
+	CompletedIterations *int32                              `json:"completedIterations,omitempty"`
+	AggregatedMetrics   *v2alpha2.AggregatedMetricsAnalysis `json:"aggregatedMetrics,omitempty"`
+	VersionAssessments  *v2alpha2.VersionAssessmentAnalysis `json:"versionAssessments,omitempty"`
+	// Weights are the traffic weights applied to versions
+	Weights          []v2alpha2.WeightData              `json:"weights,omitempty"`
+	WinnerAssessment *v2alpha2.WinnerAssessmentAnalysis `json:"winnerAssessment,omitempty"`
+}
+
+// GetSnapshot returns a snapshot of the analysis of the experiment, taken at time t.
+func (e *Experiment) GetSnapshot(t time.Time) Snapshot {
+	s := Snapshot{
+		Time:                metav1.NewTime(t),
+		Namespace:           e.Namespace,
+		Name:                e.Name,
+		Versions:            e.GetVersions(),
+		Stage:               e.Status.Stage,
+		CompletedIterations: e.Status.CompletedIterations,
+		Weights:             e.Status.CurrentWeightDistribution,
+	}
+	if a := e.Status.Analysis; a != nil {
+		s.AggregatedMetrics = a.AggregatedMetrics
+		s.VersionAssessments = a.VersionAssessments
+		s.WinnerAssessment = a.WinnerAssessment
+	}
+	return s
+}
+
+// SameStatus indicates if two snapshots record the same status; that is, if they are equal except for the time at which they were taken.
+func (s Snapshot) SameStatus(other Snapshot) bool {
+	other.Time = s.Time
+	return equality.Semantic.DeepEqual(s, other)
+}
+
+// Experiment returns an experiment whose versions and status are those recorded in the snapshot, so that the accessors of Experiment, such as GetMetricValue and GetAnalysis, can be used with the snapshot.
+// The spec of the experiment is otherwise empty; in particular, it has no criteria.
+func (s Snapshot) Experiment() *Experiment {
+	exp := &Experiment{}
+	exp.Namespace = s.Namespace
+	exp.Name = s.Name
+	if len(s.Versions) > 0 {
+		exp.Spec.VersionInfo = &v2alpha2.VersionInfo{
+			Baseline: v2alpha2.VersionDetail{Name: s.Versions[0]},
+		}
+		for _, c := range s.Versions[1:] {
+			exp.Spec.VersionInfo.Candidates = append(exp.Spec.VersionInfo.Candidates, v2alpha2.VersionDetail{Name: c})
+		}
+	}
+	exp.Status.Stage = s.Stage
+	exp.Status.CompletedIterations = s.CompletedIterations
+	exp.Status.CurrentWeightDistribution = s.Weights
+	exp.Status.Analysis = &v2alpha2.Analysis{
+		AggregatedMetrics:  s.AggregatedMetrics,
+		VersionAssessments: s.VersionAssessments,
+		WinnerAssessment:   s.WinnerAssessment,
+	}
+	return exp
+}
+
+// WriteSnapshot appends the snapshot to w as a line of JSON.
+func WriteSnapshot(w io.Writer, s Snapshot) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// ReadSnapshots reads snapshots written by WriteSnapshot, one per line, from r. Blank lines are ignored.
+func ReadSnapshots(r io.Reader) ([]Snapshot, error) {
+	var snapshots []Snapshot
+	scanner := bufio.NewScanner(r)
+	// snapshots of experiments with many metrics may exceed the default maximum line length
+	scanner.Buffer(nil, 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var s Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, NewError(KindUsage, fmt.Sprintf("invalid snapshot on line %d: %v", line, err))
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, scanner.Err()
+}
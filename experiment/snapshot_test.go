@@ -0,0 +1,60 @@
+package experiment
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := exp.GetSnapshot(now)
+	assert.Equal(t, []string{"default", "canary"}, s.Versions)
+	assert.Equal(t, int32(10), *s.CompletedIterations)
+	assert.Len(t, s.Weights, 2)
+
+	// snapshots taken at different times record the same status
+	later := exp.GetSnapshot(now.Add(time.Minute))
+	assert.True(t, s.SameStatus(later))
+	iterations := int32(11)
+	later.CompletedIterations = &iterations
+	assert.False(t, s.SameStatus(later))
+
+	// accessors of the experiment are available for the snapshot
+	e := s.Experiment()
+	assert.Equal(t, exp.GetVersions(), e.GetVersions())
+	assert.Equal(t, exp.GetMetricStr("mean-latency", "canary"), e.GetMetricStr("mean-latency", "canary"))
+	assert.Equal(t, "canary", *e.GetAnalysis().Winner)
+	assert.Equal(t, int32(85), *e.GetWeight("canary"))
+
+	// snapshots round trip through JSON lines
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSnapshot(&buf, s))
+	buf.WriteString("\n")
+	assert.NoError(t, WriteSnapshot(&buf, later))
+	snapshots, err := ReadSnapshots(&buf)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	assert.True(t, s.SameStatus(snapshots[0]))
+	assert.True(t, snapshots[0].Time.Equal(&s.Time))
+	assert.True(t, later.SameStatus(snapshots[1]))
+}
+
+func TestReadSnapshots(t *testing.T) {
+	f, err := os.Open(utils.CompletePath("../", "testdata/history.jsonl"))
+	assert.NoError(t, err)
+	defer f.Close()
+	snapshots, err := ReadSnapshots(f)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 4)
+
+	_, err = ReadSnapshots(bytes.NewBufferString("{}\nnot json\n"))
+	assert.EqualError(t, err, "invalid snapshot on line 2: invalid character 'o' in literal null (expecting 'u')")
+	assert.Equal(t, KindUsage, KindOf(err))
+}
@@ -0,0 +1,188 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Event is a change to an experiment observed by WatchExperiments.
+type Event struct {
+	// Type is watch.Added, watch.Modified, or watch.Deleted
+	Type       watch.EventType
+	Experiment *Experiment
+}
+
+// rewatchInterval is the time to wait before restarting a watch closed by the cluster.
+var rewatchInterval = time.Second
+
+// Synced is the type of the event sent by WatchExperimentsSynced once the events for a list of experiments have been sent; its Experiment is nil.
+const Synced watch.EventType = "SYNCED"
+
+// WatchExperiments sends an Added event for every experiment in the given namespace, or in all namespaces if namespace is empty, followed by an event for every subsequent change to these experiments.
+// If the cluster ends the watch, experiments are listed and watched again; hence, Added events may repeat, and a Deleted event is sent for every experiment deleted while it was not watched.
+// Events are sent on the returned channel, which is closed when ctx is done, or when experiments cannot be listed again.
+func WatchExperiments(ctx context.Context, namespace string) (<-chan Event, error) {
+	return watchExperiments(ctx, namespace, false)
+}
+
+// WatchExperimentsSynced is WatchExperiments, except that an event of type Synced is also sent after the events for each list of experiments, so that consumers know when they have observed every listed experiment.
+func WatchExperimentsSynced(ctx context.Context, namespace string) (<-chan Event, error) {
+	return watchExperiments(ctx, namespace, true)
+}
+
+// watchExperiments implements WatchExperiments and WatchExperimentsSynced.
+func watchExperiments(ctx context.Context, namespace string, synced bool) (<-chan Event, error) {
+	rc, err := GetWatchClient()
+	if err != nil {
+		return nil, clusterError(err)
+	}
+	list, w, err := listAndWatch(ctx, rc, namespace)
+	if err != nil {
+		return nil, clusterError(err)
+	}
+
+	events := make(chan Event)
+	// sent holds the last experiment sent for every experiment which has not been deleted
+	sent := map[types.NamespacedName]*v2alpha2.Experiment{}
+	send := func(t watch.EventType, exp *v2alpha2.Experiment) bool {
+		ev := Event{Type: t}
+		if exp != nil {
+			ev.Experiment = &Experiment{*exp}
+			k := types.NamespacedName{Namespace: exp.Namespace, Name: exp.Name}
+			if t == watch.Deleted {
+				delete(sent, k)
+			} else {
+				sent[k] = exp
+			}
+		}
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(events)
+		for {
+			listed := map[types.NamespacedName]bool{}
+			for i := range list.Items {
+				listed[types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name}] = true
+			}
+			var deleted []*v2alpha2.Experiment
+			for k, exp := range sent {
+				if !listed[k] {
+					deleted = append(deleted, exp)
+				}
+			}
+			for i := range list.Items {
+				if !send(watch.Added, &list.Items[i]) {
+					w.Stop()
+					return
+				}
+			}
+			for _, exp := range deleted {
+				if !send(watch.Deleted, exp) {
+					w.Stop()
+					return
+				}
+			}
+			if synced && !send(Synced, nil) {
+				w.Stop()
+				return
+			}
+			if !forwardEvents(ctx, w, send) {
+				return
+			}
+			// the watch was ended by the cluster
+			select {
+			case <-time.After(rewatchInterval):
+			case <-ctx.Done():
+				return
+			}
+			if list, w, err = listAndWatch(ctx, rc, namespace); err != nil {
+				log.Error(err)
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// listAndWatch lists experiments in the namespace, and watches them starting from the resource version of the list.
+func listAndWatch(ctx context.Context, rc client.WithWatch, namespace string) (*v2alpha2.ExperimentList, watch.Interface, error) {
+	list := &v2alpha2.ExperimentList{}
+	if err := rc.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
+	}
+	w, err := rc.Watch(ctx, &v2alpha2.ExperimentList{}, client.InNamespace(namespace), &client.ListOptions{
+		Raw: &metav1.ListOptions{ResourceVersion: list.ResourceVersion},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, w, nil
+}
+
+// forwardEvents sends experiment events from w until w ends or reports an error, in which case it returns true, or until ctx is done or send fails, in which case it returns false.
+func forwardEvents(ctx context.Context, w watch.Interface, send func(watch.EventType, *v2alpha2.Experiment) bool) bool {
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case ev, ok := <-w.ResultChan():
+			if !ok || ev.Type == watch.Error {
+				return true
+			}
+			exp, ok := ev.Object.(*v2alpha2.Experiment)
+			if !ok {
+				continue
+			}
+			if !send(ev.Type, exp) {
+				return false
+			}
+		}
+	}
+}
+
+// WaitForExperiment waits until the experiment with the given namespace and name satisfies cond, and returns it.
+// An error of kind KindTimeout is returned if ctx is done first, and an error of kind KindNotFound if the experiment does not exist or is deleted.
+func WaitForExperiment(ctx context.Context, namespace string, name string, cond func(*Experiment) bool) (*Experiment, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := WatchExperimentsSynced(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for ev := range events {
+		if ev.Type == Synced {
+			if !found {
+				return nil, NewError(KindNotFound, fmt.Sprintf("Experiment %s not found in namespace %s", name, namespace))
+			}
+			continue
+		}
+		if ev.Experiment.Name != name {
+			continue
+		}
+		if ev.Type == watch.Deleted {
+			return nil, NewError(KindNotFound, fmt.Sprintf("Experiment %s was deleted from namespace %s", name, namespace))
+		}
+		found = true
+		if cond(ev.Experiment) {
+			return ev.Experiment, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, &Error{Kind: KindTimeout, Err: fmt.Errorf("timed out waiting for experiment %s/%s: %w", namespace, name, ctx.Err())}
+	}
+	return nil, NewError(KindCluster, "watch of experiments ended")
+}
@@ -0,0 +1,161 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nextEvent returns the next event on events, or fails the test if none arrives in time.
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	select {
+	case ev, ok := <-events:
+		assert.True(t, ok, "events closed")
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestWatchExperiments(t *testing.T) {
+	exp, err := getExp("experiment8")
+	assert.NoError(t, err)
+	exp.ResourceVersion = ""
+
+	rc := testutil.WithFakeObjects(t, nil, &GetWatchClient, &exp.Experiment)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := WatchExperiments(ctx, exp.Namespace)
+	assert.NoError(t, err)
+
+	ev := nextEvent(t, events)
+	assert.Equal(t, watch.Added, ev.Type)
+	assert.Equal(t, exp.Name, ev.Experiment.Name)
+	assert.Equal(t, int32(85), *ev.Experiment.GetWeight("canary"))
+	assert.Nil(t, ev.Experiment.GetWeight("missing"))
+
+	updated := ev.Experiment.Experiment.DeepCopy()
+	updated.Status.CurrentWeightDistribution[1].Value = 100
+	assert.NoError(t, rc.Status().Update(context.Background(), updated))
+	ev = nextEvent(t, events)
+	assert.Equal(t, watch.Modified, ev.Type)
+	assert.Equal(t, int32(100), *ev.Experiment.GetWeight("canary"))
+
+	assert.NoError(t, rc.Delete(context.Background(), updated))
+	ev = nextEvent(t, events)
+	assert.Equal(t, watch.Deleted, ev.Type)
+
+	cancel()
+	for range events {
+	}
+}
+
+// stoppableClient records the watches started using it, so that tests can end them as the cluster would.
+type stoppableClient struct {
+	client.WithWatch
+	watches chan watch.Interface
+}
+
+func (c *stoppableClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	w, err := c.WithWatch.Watch(ctx, list, opts...)
+	if err == nil {
+		c.watches <- w
+	}
+	return w, err
+}
+
+func TestWatchExperimentsRewatch(t *testing.T) {
+	exp8, err := getExp("experiment8")
+	assert.NoError(t, err)
+	exp8.ResourceVersion = ""
+	exp12, err := getExp("experiment12")
+	assert.NoError(t, err)
+	exp12.ResourceVersion = ""
+
+	rc := &stoppableClient{
+		WithWatch: testutil.WithFakeObjects(t, nil, nil, &exp8.Experiment, &exp12.Experiment),
+		watches:   make(chan watch.Interface, 2),
+	}
+	testutil.InstallClient(t, nil, &GetWatchClient, rc)
+	interval := rewatchInterval
+	rewatchInterval = 100 * time.Millisecond
+	defer func() {
+		rewatchInterval = interval
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := WatchExperiments(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, watch.Added, nextEvent(t, events).Type)
+	assert.Equal(t, watch.Added, nextEvent(t, events).Type)
+
+	// the cluster ends the watch, and an experiment is deleted before it is watched again
+	(<-rc.watches).Stop()
+	assert.NoError(t, rc.Delete(context.Background(), &exp12.Experiment))
+
+	ev := nextEvent(t, events)
+	assert.Equal(t, watch.Added, ev.Type)
+	assert.Equal(t, exp8.Name, ev.Experiment.Name)
+	ev = nextEvent(t, events)
+	assert.Equal(t, watch.Deleted, ev.Type)
+	assert.Equal(t, exp12.Namespace, ev.Experiment.Namespace)
+	assert.Equal(t, exp12.Name, ev.Experiment.Name)
+}
+
+func TestWatchExperimentsError(t *testing.T) {
+	getWatchClient := GetWatchClient
+	GetWatchClient = func() (client.WithWatch, error) {
+		return nil, context.DeadlineExceeded
+	}
+	defer func() {
+		GetWatchClient = getWatchClient
+	}()
+	_, err := WatchExperiments(context.Background(), "")
+	assert.Equal(t, KindTimeout, KindOf(err))
+}
+
+func TestWaitForExperiment(t *testing.T) {
+	exp, err := getExp("experiment5")
+	assert.NoError(t, err)
+	exp.ResourceVersion = ""
+	rc := testutil.WithFakeObjects(t, &GetClient, &GetWatchClient, &exp.Experiment)
+
+	// conditions which already hold are satisfied immediately
+	e, err := WaitForExperiment(context.Background(), exp.Namespace, exp.Name, (*Experiment).Started)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), e.Status.GetCompletedIterations())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		updated := exp.Experiment.DeepCopy()
+		iterations := int32(8)
+		updated.Status.CompletedIterations = &iterations
+		rc.Status().Update(context.Background(), updated)
+	}()
+	e, err = WaitForExperiment(context.Background(), exp.Namespace, exp.Name, func(e *Experiment) bool {
+		return e.Status.GetCompletedIterations() == 8
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), e.Status.GetCompletedIterations())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = WaitForExperiment(ctx, exp.Namespace, exp.Name, (*Experiment).Completed)
+	assert.Equal(t, KindTimeout, KindOf(err))
+
+	// missing experiments are reported without waiting for ctx
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = WaitForExperiment(ctx, exp.Namespace, "missing", (*Experiment).Completed)
+	assert.EqualError(t, err, "Experiment missing not found in namespace kfserving-test")
+	assert.Equal(t, KindNotFound, KindOf(err))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
@@ -4,16 +4,22 @@ go 1.15
 
 require (
 	github.com/ghodss/yaml v1.0.0
+	github.com/google/cel-go v0.7.3
 	github.com/iter8-tools/etc3 v0.1.28
 	github.com/iter8-tools/handler v0.1.20
 	github.com/olekukonko/tablewriter v0.0.5
 	github.com/onsi/ginkgo v1.16.4
 	github.com/onsi/gomega v1.13.0
+	github.com/prometheus/client_golang v1.11.0
+	github.com/prometheus/common v0.26.0
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v1.2.1
+	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.8.1
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d
 	golang.org/x/tools v0.1.5 // indirect
+	google.golang.org/genproto v0.0.0-20210602131652-f16073e35f0c
 	gopkg.in/inf.v0 v0.9.1
 	k8s.io/api v0.21.2
 	k8s.io/apimachinery v0.21.2
@@ -0,0 +1,68 @@
+// Package testutil provides helpers shared by the tests of iter8ctl packages, such as fake K8s clients containing testdata experiments.
+package testutil
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// ReadExperiment returns the experiment in the given file of the testdata directory.
+func ReadExperiment(t *testing.T, file string) *v2alpha2.Experiment {
+	buf, err := ioutil.ReadFile(utils.CompletePath("../../testdata", file))
+	assert.NoError(t, err)
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, yaml.Unmarshal(buf, e))
+	return e
+}
+
+// InstallClient replaces the client hooks, such as experiment.GetClient and experiment.GetWatchClient, with hooks returning rc until the test completes. Nil hooks are left unchanged.
+// The hooks are passed as pointers, since the experiment package cannot be imported by its own tests through this package.
+func InstallClient(t *testing.T, getClient *func() (client.Client, error), getWatchClient *func() (client.WithWatch, error), rc client.WithWatch) {
+	if getClient != nil {
+		orig := *getClient
+		*getClient = func() (client.Client, error) {
+			return rc, nil
+		}
+		t.Cleanup(func() {
+			*getClient = orig
+		})
+	}
+	if getWatchClient != nil {
+		orig := *getWatchClient
+		*getWatchClient = func() (client.WithWatch, error) {
+			return rc, nil
+		}
+		t.Cleanup(func() {
+			*getWatchClient = orig
+		})
+	}
+}
+
+// WithFakeObjects installs a fake client containing the given objects using InstallClient, and returns the fake client.
+// The scheme of the client includes experiments and core K8s types, such as events; objects of other types may be supplied as unstructured objects.
+func WithFakeObjects(t *testing.T, getClient *func() (client.Client, error), getWatchClient *func() (client.WithWatch, error), objs ...client.Object) client.WithWatch {
+	s := runtime.NewScheme()
+	assert.NoError(t, v2alpha2.AddToScheme(s))
+	assert.NoError(t, corev1.AddToScheme(s))
+	rc := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	InstallClient(t, getClient, getWatchClient, rc)
+	return rc
+}
+
+// WithFakeClient installs a fake client containing the experiments in the given files of the testdata directory using InstallClient, and returns the fake client.
+func WithFakeClient(t *testing.T, getClient *func() (client.Client, error), getWatchClient *func() (client.WithWatch, error), files ...string) client.WithWatch {
+	objs := make([]client.Object, len(files))
+	for i, f := range files {
+		objs[i] = ReadExperiment(t, f)
+	}
+	return WithFakeObjects(t, getClient, getWatchClient, objs...)
+}
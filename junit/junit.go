@@ -0,0 +1,139 @@
+// Package junit renders the outcome of `iter8ctl assert` as a JUnit XML report.
+package junit
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+)
+
+// FailureType is the type attribute used for all failures in the report.
+const FailureType = "AssertionFailure"
+
+// TestSuites is the root element of a JUnit XML report.
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite is a named group of test cases.
+type TestSuite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Skipped  int        `xml:"skipped,attr"`
+	Cases    []TestCase `xml:"testcase"`
+}
+
+// TestCase is a single asserted check. Failure is nil if the check passed.
+// Skipped is non-nil if the check does not apply. SystemOut carries warnings for checks which do not fail the assertion.
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// Skipped describes why a test case was skipped.
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Failure describes why a test case failed.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// addCase appends a test case to the suite; the test case fails if err is non-nil.
+func (s *TestSuite) addCase(name string, className string, err error) {
+	tc := TestCase{
+		Name:      name,
+		ClassName: className,
+	}
+	if err != nil {
+		tc.Failure = &Failure{
+			Message: err.Error(),
+			Type:    FailureType,
+			Text:    err.Error(),
+		}
+		s.Failures++
+	}
+	s.Tests++
+	s.Cases = append(s.Cases, tc)
+}
+
+// addResult appends a test case for the condition result to the suite.
+func (s *TestSuite) addResult(r expr.ConditionResult, className string) {
+	switch {
+	case r.Skipped:
+		s.Cases = append(s.Cases, TestCase{
+			Name:      r.Name,
+			ClassName: className,
+			Skipped:   &Skipped{Message: r.Reason},
+		})
+		s.Tests++
+		s.Skipped++
+	case r.Warned():
+		s.addCase(r.Name, className, nil)
+		s.Cases[len(s.Cases)-1].SystemOut = "warning: " + r.Reason
+	case r.Failed():
+		s.addCase(r.Name, className, errors.New(r.Reason))
+	default:
+		s.addCase(r.Name, className, nil)
+	}
+}
+
+// Build constructs a JUnit report for the experiment.
+// The report contains one test suite with a test case per entry in the assertion result, and another with a test case per objective/version pair.
+func Build(exp *expr.Experiment, res *expr.AssertResult) *TestSuites {
+	id := exp.Namespace + "/" + exp.Name
+	className := "iter8ctl.assert." + exp.Namespace + "." + exp.Name
+
+	conds := TestSuite{Name: id + " conditions"}
+	for _, r := range res.Results {
+		conds.addResult(r, className)
+	}
+
+	objs := TestSuite{Name: id + " objectives"}
+	if exp.Spec.Criteria != nil {
+		for i := range exp.Spec.Criteria.Objectives {
+			for _, version := range exp.GetVersions() {
+				objs.addResult(exp.AssertObjective(i, version), className)
+			}
+		}
+	}
+
+	ts := &TestSuites{
+		Name:   "iter8ctl assert " + id,
+		Suites: []TestSuite{conds, objs},
+	}
+	for _, s := range ts.Suites {
+		ts.Tests += s.Tests
+		ts.Failures += s.Failures
+		ts.Skipped += s.Skipped
+	}
+	return ts
+}
+
+// Write encodes the report as indented XML, preceded by the XML header, into w.
+func (ts *TestSuites) Write(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(ts); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,70 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// getExp is a helper function for extracting an experiment object from experiment filenamePrefix
+func getExp(t *testing.T, filenamePrefix string) *expr.Experiment {
+	buf, err := ioutil.ReadFile(utils.CompletePath("../", fmt.Sprintf("testdata/%s.yaml", filenamePrefix)))
+	assert.NoError(t, err)
+	exp := &expr.Experiment{}
+	assert.NoError(t, yaml.Unmarshal(buf, exp))
+	return exp
+}
+
+/* Tests */
+
+func TestBuildSatisfied(t *testing.T) {
+	exp := getExp(t, "experiment8")
+	ts := Build(exp, exp.Assert([]expr.ConditionType{expr.Completed, expr.WinnerFound}))
+	assert.Equal(t, 6, ts.Tests)
+	assert.Equal(t, 0, ts.Failures)
+	assert.Equal(t, 2, len(ts.Suites))
+	assert.Equal(t, "completed", ts.Suites[0].Cases[0].Name)
+	assert.Equal(t, "mean-latency <= 1000.000 [default]", ts.Suites[1].Cases[0].Name)
+}
+
+func TestBuildUnsatisfied(t *testing.T) {
+	exp := getExp(t, "experiment1")
+	ts := Build(exp, exp.Assert([]expr.ConditionType{expr.Completed, expr.WinnerFound}))
+	assert.Equal(t, 2, ts.Tests)
+	assert.Equal(t, 2, ts.Failures)
+	assert.Equal(t, "experiment has not completed", ts.Suites[0].Cases[0].Failure.Message)
+	assert.Equal(t, "no winner found in experiment", ts.Suites[0].Cases[1].Failure.Message)
+}
+
+func TestWrite(t *testing.T) {
+	exp := getExp(t, "experiment8")
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Build(exp, exp.Assert([]expr.ConditionType{expr.Completed})).Write(buf))
+	assert.Contains(t, buf.String(), xml.Header)
+
+	ts := &TestSuites{}
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), ts))
+	assert.Equal(t, 5, ts.Tests)
+	assert.Nil(t, ts.Suites[0].Cases[0].Failure)
+}
+
+func TestBuildWarningsAndSkipped(t *testing.T) {
+	exp := getExp(t, "experiment8")
+	res := &expr.AssertResult{Results: []expr.ConditionResult{
+		{Name: "warned", Reason: "too few iterations", Severity: expr.SeverityWarn},
+		{Name: "skipped", Reason: "does not apply", Skipped: true},
+	}}
+	ts := Build(exp, res)
+	assert.Equal(t, 0, ts.Failures)
+	assert.Equal(t, 1, ts.Skipped)
+	assert.Equal(t, "warning: too few iterations", ts.Suites[0].Cases[0].SystemOut)
+	assert.Nil(t, ts.Suites[0].Cases[0].Failure)
+	assert.Equal(t, "does not apply", ts.Suites[0].Cases[1].Skipped.Message)
+}
@@ -0,0 +1,198 @@
+// Package notify implements `iter8ctl notify`, which watches Iter8 experiments and notifies a webhook when they complete, find a winner, or fail.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tasks "github.com/iter8-tools/handler/tasks"
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var log *logrus.Logger
+
+func init() {
+	log = tasks.GetLogger()
+}
+
+// Now returns the current time; it is a variable so that tests can fix the time of notifications.
+var Now = time.Now
+
+// Trigger is a transition of an experiment for which a notification is sent.
+type Trigger string
+
+const (
+	// TriggerCompleted is sent when the experiment completes
+	TriggerCompleted Trigger = "completed"
+	// TriggerWinnerFound is sent when the experiment finds a winner
+	TriggerWinnerFound Trigger = "winnerFound"
+	// TriggerFailure is sent when the experiment fails
+	TriggerFailure Trigger = "failure"
+)
+
+// AllTriggers lists every trigger in the order in which simultaneous transitions are notified.
+var AllTriggers = []Trigger{TriggerWinnerFound, TriggerFailure, TriggerCompleted}
+
+// ParseTrigger returns the trigger with the given name.
+func ParseTrigger(s string) (Trigger, error) {
+	for _, t := range AllTriggers {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", errors.New("Invalid trigger: " + s)
+}
+
+// holds indicates if the condition named by the trigger holds for the experiment.
+func (t Trigger) holds(exp *expr.Experiment) bool {
+	switch t {
+	case TriggerCompleted:
+		return exp.Completed()
+	case TriggerWinnerFound:
+		return exp.WinnerFound()
+	case TriggerFailure:
+		return exp.Failed()
+	default:
+		return false
+	}
+}
+
+// verb describes the transition in a sentence of the form "Experiment <namespace>/<name> <verb>".
+func (t Trigger) verb() string {
+	switch t {
+	case TriggerWinnerFound:
+		return "found a winner"
+	case TriggerFailure:
+		return "failed"
+	default:
+		return string(t)
+	}
+}
+
+// Tracker remembers which triggers hold for each experiment, so that a notification is sent only when a trigger begins to hold.
+type Tracker struct {
+	triggers []Trigger
+	state    map[string]map[Trigger]bool
+}
+
+// NewTracker returns a tracker for the given triggers, which have not been seen to hold for any experiment.
+func NewTracker(triggers []Trigger) *Tracker {
+	return &Tracker{triggers: triggers, state: map[string]map[Trigger]bool{}}
+}
+
+// Update records the triggers which hold for the experiment in the event, and returns those which did not hold when the experiment was last seen, in the order of AllTriggers.
+// Every trigger which holds for an experiment seen for the first time is returned. Deleted experiments are forgotten.
+func (t *Tracker) Update(ev expr.Event) []Trigger {
+	k := ev.Experiment.Namespace + "/" + ev.Experiment.Name
+	if ev.Type == watch.Deleted {
+		delete(t.state, k)
+		return nil
+	}
+	last := t.state[k]
+	current := map[Trigger]bool{}
+	var transitions []Trigger
+	for _, trigger := range AllTriggers {
+		if !t.watches(trigger) || !trigger.holds(ev.Experiment) {
+			continue
+		}
+		current[trigger] = true
+		if !last[trigger] {
+			transitions = append(transitions, trigger)
+		}
+	}
+	t.state[k] = current
+	return transitions
+}
+
+// watches indicates if the tracker reports transitions of the trigger.
+func (t *Tracker) watches(trigger Trigger) bool {
+	for _, tr := range t.triggers {
+		if tr == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body of a notification.
+type Payload struct {
+	// Event is the trigger for which the notification is sent
+	Event   Trigger   `json:"event"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	// Summary is the compact description of the experiment, as with 'iter8ctl describe -o compact'
+	Summary string           `json:"summary"`
+	Report  *describe.Report `json:"report"`
+}
+
+// NewPayload returns the payload notifying that the trigger holds for the experiment at time t.
+func NewPayload(exp *expr.Experiment, trigger Trigger, t time.Time) (*Payload, error) {
+	var summary strings.Builder
+	if err := describe.Builder().WithExperiment(exp).Render(&summary, describe.FormatCompact).Error(); err != nil {
+		return nil, err
+	}
+	return &Payload{
+		Event:   trigger,
+		Message: fmt.Sprintf("Experiment %s/%s %s", exp.Namespace, exp.Name, trigger.verb()),
+		Time:    t.UTC(),
+		Summary: summary.String(),
+		Report:  describe.NewReport(exp),
+	}, nil
+}
+
+// Notifier watches experiments and notifies a webhook of their transitions.
+type Notifier struct {
+	webhook *Webhook
+	tracker *Tracker
+}
+
+// NewNotifier returns a notifier which sends notifications for the given triggers to the webhook.
+func NewNotifier(webhook *Webhook, triggers []Trigger) *Notifier {
+	return &Notifier{webhook: webhook, tracker: NewTracker(triggers)}
+}
+
+// Run watches experiments in the given namespace, or in all namespaces if namespace is empty, and notifies the webhook of their transitions until ctx is done; a line is written to out for every notification sent.
+// Triggers which already hold for experiments when Run starts are not notified. Notifications which cannot be delivered are logged, and do not stop Run.
+func (n *Notifier) Run(ctx context.Context, namespace string, out io.Writer) error {
+	exps, err := expr.ListExperiments(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	for _, exp := range exps {
+		n.tracker.Update(expr.Event{Type: watch.Added, Experiment: exp})
+	}
+	events, err := expr.WatchExperiments(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	for ev := range events {
+		for _, trigger := range n.tracker.Update(ev) {
+			n.notify(ctx, ev.Experiment, trigger, out)
+		}
+	}
+	if ctx.Err() != nil {
+		// interrupted
+		return nil
+	}
+	return expr.NewError(expr.KindCluster, "watch of experiments ended")
+}
+
+// notify sends a notification that the trigger holds for the experiment.
+func (n *Notifier) notify(ctx context.Context, exp *expr.Experiment, trigger Trigger, out io.Writer) {
+	p, err := NewPayload(exp, trigger, Now())
+	if err == nil {
+		err = n.webhook.Send(ctx, p)
+	}
+	if err != nil {
+		log.Errorf("cannot notify webhook of %s for experiment %s/%s: %v", trigger, exp.Namespace, exp.Name, err)
+		return
+	}
+	fmt.Fprintf(out, "Notified webhook of %s for experiment %s/%s\n", trigger, exp.Namespace, exp.Name)
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// signalingClient closes watching once a watch has started.
+type signalingClient struct {
+	client.WithWatch
+	watching chan struct{}
+}
+
+func (c *signalingClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	w, err := c.WithWatch.Watch(ctx, list, opts...)
+	close(c.watching)
+	return w, err
+}
+
+// recorder is a webhook which records the notifications it receives, and responds to them with the given statuses in turn, followed by 200 OK.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if len(r.statuses) > 0 {
+		w.WriteHeader(r.statuses[0])
+		r.statuses = r.statuses[1:]
+	}
+}
+
+// received returns the number of notifications received.
+func (r *recorder) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// payload decodes the i'th notification received.
+func (r *recorder) payload(t *testing.T, i int) Payload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var p Payload
+	assert.NoError(t, json.Unmarshal(r.bodies[i], &p))
+	return p
+}
+
+func TestParseTrigger(t *testing.T) {
+	trigger, err := ParseTrigger("winnerFound")
+	assert.NoError(t, err)
+	assert.Equal(t, TriggerWinnerFound, trigger)
+	_, err = ParseTrigger("done")
+	assert.EqualError(t, err, "Invalid trigger: done")
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker([]Trigger{TriggerCompleted, TriggerWinnerFound})
+	completed := &expr.Experiment{Experiment: *testutil.ReadExperiment(t, "experiment8.yaml")}
+	running := &expr.Experiment{Experiment: *completed.DeepCopy()}
+	running.Status.Conditions, running.Status.Analysis = nil, nil
+
+	assert.Empty(t, tracker.Update(expr.Event{Type: watch.Added, Experiment: running}))
+	assert.Equal(t, []Trigger{TriggerWinnerFound, TriggerCompleted}, tracker.Update(expr.Event{Type: watch.Modified, Experiment: completed}))
+	assert.Empty(t, tracker.Update(expr.Event{Type: watch.Modified, Experiment: completed}))
+
+	// experiments seen again after deletion are new
+	assert.Empty(t, tracker.Update(expr.Event{Type: watch.Deleted, Experiment: completed}))
+	assert.Len(t, tracker.Update(expr.Event{Type: watch.Added, Experiment: completed}), 2)
+}
+
+func TestNewPayload(t *testing.T) {
+	exp := &expr.Experiment{Experiment: *testutil.ReadExperiment(t, "experiment8.yaml")}
+	p, err := NewPayload(exp, TriggerWinnerFound, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "Experiment kfserving-test/sklearn-iris-experiment-1 found a winner", p.Message)
+	assert.Contains(t, p.Summary, "kfserving-test/sklearn-iris-experiment-1: stage unavailable, 10 iterations, winner canary\n")
+	assert.Equal(t, "canary", p.Report.Winner)
+}
+
+func TestSend(t *testing.T) {
+	r := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	h := NewWebhook(srv.URL)
+	h.Secret = []byte("secret")
+	h.Backoff = time.Millisecond
+
+	exp := &expr.Experiment{Experiment: *testutil.ReadExperiment(t, "experiment8.yaml")}
+	p, err := NewPayload(exp, TriggerCompleted, time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, h.Send(context.Background(), p))
+	assert.Equal(t, 3, r.received())
+	req := r.requests[2]
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "completed", req.Header.Get(EventHeader))
+	assert.Equal(t, Sign([]byte("secret"), r.bodies[2]), req.Header.Get(SignatureHeader))
+	assert.Equal(t, "Experiment kfserving-test/sklearn-iris-experiment-1 completed", r.payload(t, 2).Message)
+}
+
+func TestSendFailure(t *testing.T) {
+	r := &recorder{statuses: []int{http.StatusBadRequest}}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	h := NewWebhook(srv.URL)
+	h.Backoff = time.Millisecond
+
+	exp := &expr.Experiment{Experiment: *testutil.ReadExperiment(t, "experiment8.yaml")}
+	p, err := NewPayload(exp, TriggerCompleted, time.Now())
+	assert.NoError(t, err)
+	// 4xx responses other than 429 are not retried
+	assert.EqualError(t, h.Send(context.Background(), p), "webhook responded with 400 Bad Request")
+	assert.Equal(t, 1, r.received())
+	assert.Empty(t, r.requests[0].Header.Get(SignatureHeader))
+
+	r.statuses = []int{500, 500, 500, 500}
+	h.Retries = 2
+	assert.EqualError(t, h.Send(context.Background(), p), "webhook responded with 500 Internal Server Error")
+	assert.Equal(t, 4, r.received())
+
+	// negative retries are treated as no retries
+	r.statuses = []int{500, 500}
+	h.Retries = -1
+	assert.EqualError(t, h.Send(context.Background(), p), "webhook responded with 500 Internal Server Error")
+	assert.Equal(t, 5, r.received())
+}
+
+func TestSendSlack(t *testing.T) {
+	r := &recorder{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	h := NewWebhook(srv.URL)
+	h.Format = FormatSlack
+
+	exp := &expr.Experiment{Experiment: *testutil.ReadExperiment(t, "experiment8.yaml")}
+	p, err := NewPayload(exp, TriggerFailure, time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, h.Send(context.Background(), p))
+	var msg slackMessage
+	assert.NoError(t, json.Unmarshal(r.bodies[0], &msg))
+	assert.Equal(t, "Experiment kfserving-test/sklearn-iris-experiment-1 failed", msg.Text)
+	assert.Len(t, msg.Blocks, 2)
+	assert.Equal(t, "header", msg.Blocks[0].Type)
+	assert.Contains(t, msg.Blocks[1].Text.Text, "winner canary")
+}
+
+func TestRun(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml", "experiment12.yaml")
+	// experiments are watched once the notifier has seen their initial state
+	wc := &signalingClient{WithWatch: rc, watching: make(chan struct{})}
+	testutil.InstallClient(t, nil, &expr.GetWatchClient, wc)
+	r := &recorder{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &bytes.Buffer{}
+	done := make(chan error)
+	n := NewNotifier(NewWebhook(srv.URL), AllTriggers)
+	go func() {
+		done <- n.Run(ctx, "", out)
+	}()
+	<-wc.watching
+
+	// experiment12 had already completed when Run started, and is not notified
+	key := client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(ctx, key, e))
+	e.Status.MarkCondition(v2alpha2.ExperimentConditionExperimentFailed, corev1.ConditionTrue, v2alpha2.ReasonMetricUnavailable, "metric unavailable")
+	e.Status.MarkCondition(v2alpha2.ExperimentConditionExperimentCompleted, corev1.ConditionTrue, v2alpha2.ReasonExperimentCompleted, "Experiment completed")
+	assert.NoError(t, rc.Status().Update(ctx, e))
+
+	assert.Eventually(t, func() bool {
+		return r.received() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, TriggerFailure, r.payload(t, 0).Event)
+	assert.Equal(t, TriggerCompleted, r.payload(t, 1).Event)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notify did not stop when interrupted")
+	}
+	assert.Equal(t, "Notified webhook of failure for experiment kfserving-test/sklearn-iris-experiment-1\nNotified webhook of completed for experiment kfserving-test/sklearn-iris-experiment-1\n", out.String())
+}
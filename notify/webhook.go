@@ -0,0 +1,158 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Format is the format of the body of notifications.
+type Format string
+
+const (
+	// FormatJSON is the Payload as JSON
+	FormatJSON Format = "json"
+	// FormatSlack is a Slack message with blocks, as accepted by Slack incoming webhooks
+	FormatSlack Format = "slack"
+)
+
+// Headers of notification requests.
+const (
+	// EventHeader contains the trigger for which the notification is sent
+	EventHeader = "X-Iter8ctl-Event"
+	// SignatureHeader contains the HMAC-SHA256 signature of the body as sha256=<hex digest>; it is only set when the webhook has a secret
+	SignatureHeader = "X-Iter8ctl-Signature-256"
+)
+
+// Webhook is an HTTP endpoint to which notifications are POSTed.
+type Webhook struct {
+	URL    string
+	Format Format
+	// Secret is the key with which the body of notifications is signed; notifications are not signed if it is empty
+	Secret []byte
+	// Retries is the number of times a notification is retried after a network error, or a 429 or 5xx response
+	Retries int
+	// Backoff is the delay before the first retry; the delay doubles with every retry
+	Backoff time.Duration
+	Client  *http.Client
+}
+
+// NewWebhook returns a webhook for the URL with JSON notifications, which are retried 3 times starting after a second.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL:     url,
+		Format:  FormatJSON,
+		Retries: 3,
+		Backoff: time.Second,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatJSON, FormatSlack:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("invalid notification format: %v; expected one of %v, %v", s, FormatJSON, FormatSlack)
+	}
+}
+
+// Sign returns the value of SignatureHeader for the body signed with the secret.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// slackMessage is a Slack message with blocks.
+type slackMessage struct {
+	// Text is shown in notifications, and by clients which cannot render blocks
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// body returns the body of the notification for the payload in the format of the webhook.
+func (h *Webhook) body(p *Payload) ([]byte, error) {
+	if h.Format != FormatSlack {
+		return json.Marshal(p)
+	}
+	return json.Marshal(slackMessage{
+		Text: p.Message,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: p.Message}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "```" + p.Summary + "```"}},
+		},
+	})
+}
+
+// Send POSTs the payload to the webhook, retrying after network errors and 429 and 5xx responses with exponential backoff.
+// An error is returned if the notification is not accepted with a 2xx response, or if ctx is done before it is.
+func (h *Webhook) Send(ctx context.Context, p *Payload) error {
+	body, err := h.body(p)
+	if err != nil {
+		return err
+	}
+	backoff := h.Backoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = h.post(ctx, p.Event, body)
+		if err == nil || !retry || attempt >= h.Retries {
+			return err
+		}
+		log.Warnf("retrying notification to webhook in %v: %v", backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single attempt to deliver the notification, and indicates if a failed attempt may be retried.
+func (h *Webhook) post(ctx context.Context, trigger Trigger, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(trigger))
+	if len(h.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(h.Secret, body))
+	}
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook responded with %v", resp.Status)
+}
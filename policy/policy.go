@@ -0,0 +1,264 @@
+// Package policy implements promotion gates for `iter8ctl assert`, declared as named rules in a YAML policy file.
+//
+// The following is an example of a policy file.
+//  rules:
+//  - name: canary-won
+//    conditions: [completed, winnerFound]
+//    winner: candidate
+//    recommended: canary
+//  - name: low-error-rate
+//    metrics:
+//    - "iter8-istio/error-rate[canary] < 0.01"
+//    objectives: ["iter8-istio/error-rate@canary"]
+//    objectivesSatisfied: [baseline]
+//  - name: enough-iterations
+//    severity: warn
+//    minIterations: 10
+//    minSampleSize: 500
+//    maxAge: 24h
+//  - name: production-latency
+//    appliesTo:
+//      namespaces: [production]
+//    expressions:
+//    - 'metrics["iter8-istio/mean-latency"]["canary"] <= 1.1 * metrics["iter8-istio/mean-latency"]["default"]'
+// A rule is satisfied when every check it declares is satisfied. Rules with severity error (the default) fail the assertion; rules with severity warn are only reported.
+// Rules with an appliesTo section are skipped for experiments whose namespace or target is not listed.
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Now returns the current time; it is a variable so that tests can mock it.
+var Now = time.Now
+
+// Policy is a set of named rules evaluated against an experiment.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Selector restricts the experiments to which a rule applies.
+// An experiment matches the selector if its namespace is listed in Namespaces, or if its target is listed in Targets.
+type Selector struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	Targets    []string `json:"targets,omitempty"`
+}
+
+// Rule is a named promotion gate composed of one or more checks.
+type Rule struct {
+	// Name identifies the rule in results
+	Name string `json:"name"`
+	// Severity is error (default) or warn
+	Severity expr.Severity `json:"severity,omitempty"`
+	// AppliesTo restricts the rule to matching experiments; the rule applies to all experiments if it is nil
+	AppliesTo *Selector `json:"appliesTo,omitempty"`
+	// Conditions are experiment conditions, such as completed or winnerFound
+	Conditions []expr.ConditionType `json:"conditions,omitempty"`
+	// Metrics are metric threshold assertions, in the syntax accepted by `assert --metric`
+	Metrics []string `json:"metrics,omitempty"`
+	// Expressions are CEL expressions, in the syntax accepted by `assert --expr`
+	Expressions []string `json:"expressions,omitempty"`
+	// Winner is the version, or the role of the version (baseline or candidate), which must be the winner of the experiment
+	Winner *string `json:"winner,omitempty"`
+	// Recommended is the version, or the role of the version (baseline or candidate), which must be recommended for promotion
+	Recommended *string `json:"recommended,omitempty"`
+	// ObjectivesSatisfied are versions, or roles of versions, which must satisfy every objective
+	ObjectivesSatisfied []string `json:"objectivesSatisfied,omitempty"`
+	// Objectives are objectives which must be satisfied, in the syntax accepted by `assert --objective`
+	Objectives []string `json:"objectives,omitempty"`
+	// MinIterations is the minimum number of completed iterations
+	MinIterations *int32 `json:"minIterations,omitempty"`
+	// MinSampleSize is the minimum number of requests per version, and the minimum sample size of every metric value with a known sample size
+	MinSampleSize *int64 `json:"minSampleSize,omitempty"`
+	// MaxAge is the maximum time elapsed since the experiment was created
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	metricAssertions []*expr.MetricAssertion
+	expressions      []*expr.Expression
+	objectiveRefs    []expr.ObjectiveRef
+}
+
+// FromFile reads and validates a policy from a YAML file.
+func FromFile(path string) (*Policy, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(buf)
+}
+
+// Parse reads and validates a policy from YAML bytes.
+// An invalid policy is reported as an error of kind expr.KindUsage.
+func Parse(buf []byte) (*Policy, error) {
+	p, err := parse(buf)
+	if err != nil {
+		return nil, &expr.Error{Kind: expr.KindUsage, Err: err}
+	}
+	return p, nil
+}
+
+// parse reads and validates a policy from YAML bytes.
+func parse(buf []byte) (*Policy, error) {
+	j, err := yaml.YAMLToJSON(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %v", err)
+	}
+	// reject unknown fields, which are most likely misspelled checks
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.DisallowUnknownFields()
+	p := &Policy{}
+	if err := dec.Decode(p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %v", err)
+	}
+	if len(p.Rules) == 0 {
+		return nil, errors.New("invalid policy: no rules found")
+	}
+	names := map[string]bool{}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Name == "" {
+			return nil, fmt.Errorf("invalid policy: rule %v has no name", i)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("invalid policy: duplicate rule name %s", r.Name)
+		}
+		names[r.Name] = true
+		if err := r.compile(); err != nil {
+			return nil, fmt.Errorf("invalid policy: rule %s: %v", r.Name, err)
+		}
+	}
+	return p, nil
+}
+
+// compile validates the rule and compiles its metric assertions and expressions.
+func (r *Rule) compile() error {
+	switch r.Severity {
+	case "":
+		r.Severity = expr.SeverityError
+	case expr.SeverityError, expr.SeverityWarn:
+	default:
+		return fmt.Errorf("invalid severity %s; expected %s or %s", r.Severity, expr.SeverityError, expr.SeverityWarn)
+	}
+	for _, c := range r.Conditions {
+		if _, err := expr.ParseConditionType(string(c)); err != nil {
+			return err
+		}
+	}
+	for _, m := range r.Metrics {
+		ma, err := expr.ParseMetricAssertion(m)
+		if err != nil {
+			return err
+		}
+		r.metricAssertions = append(r.metricAssertions, ma)
+	}
+	for _, x := range r.Expressions {
+		cx, err := expr.CompileExpression(x)
+		if err != nil {
+			return err
+		}
+		r.expressions = append(r.expressions, cx)
+	}
+	for _, o := range r.Objectives {
+		ref, err := expr.ParseObjectiveRef(o)
+		if err != nil {
+			return err
+		}
+		r.objectiveRefs = append(r.objectiveRefs, ref)
+	}
+	if len(r.Conditions) == 0 && len(r.metricAssertions) == 0 && len(r.expressions) == 0 &&
+		r.Winner == nil && r.Recommended == nil && len(r.ObjectivesSatisfied) == 0 && len(r.objectiveRefs) == 0 &&
+		r.MinIterations == nil && r.MinSampleSize == nil && r.MaxAge == nil {
+		return errors.New("no checks found")
+	}
+	return nil
+}
+
+// appliesTo indicates if the rule applies to the experiment.
+func (r *Rule) appliesTo(exp *expr.Experiment) bool {
+	if r.AppliesTo == nil {
+		return true
+	}
+	for _, ns := range r.AppliesTo.Namespaces {
+		if ns == exp.Namespace {
+			return true
+		}
+	}
+	for _, t := range r.AppliesTo.Targets {
+		if t == exp.Spec.Target {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate evaluates the rule against the experiment.
+// The rule is satisfied if every check is satisfied; the reason lists the reasons of unsatisfied checks.
+func (r *Rule) Evaluate(exp *expr.Experiment) expr.ConditionResult {
+	res := expr.ConditionResult{
+		Name:     r.Name,
+		Severity: r.Severity,
+	}
+	if !r.appliesTo(exp) {
+		res.Skipped = true
+		res.Reason = fmt.Sprintf("rule does not apply to experiment %s/%s with target %s", exp.Namespace, exp.Name, exp.Spec.Target)
+		return res
+	}
+
+	checks := exp.Assert(r.Conditions).Results
+	for _, ma := range r.metricAssertions {
+		checks = append(checks, exp.AssertMetric(ma))
+	}
+	for _, cx := range r.expressions {
+		checks = append(checks, exp.AssertExpression(cx))
+	}
+	if r.Winner != nil {
+		checks = append(checks, exp.AssertWinner(*r.Winner))
+	}
+	if r.Recommended != nil {
+		checks = append(checks, exp.AssertRecommended(*r.Recommended))
+	}
+	for _, v := range r.ObjectivesSatisfied {
+		checks = append(checks, exp.AssertObjectivesSatisfied(v))
+	}
+	for _, ref := range r.objectiveRefs {
+		checks = append(checks, exp.AssertObjectiveRef(ref))
+	}
+	if r.MinIterations != nil {
+		checks = append(checks, exp.AssertMinIterations(*r.MinIterations))
+	}
+	if r.MinSampleSize != nil {
+		checks = append(checks, exp.AssertMinSampleSize(*r.MinSampleSize))
+	}
+	if r.MaxAge != nil {
+		checks = append(checks, exp.AssertMaxAge(r.MaxAge.Duration, Now()))
+	}
+
+	var reasons []string
+	for _, c := range checks {
+		if !c.Satisfied {
+			reasons = append(reasons, c.Reason)
+		}
+	}
+	res.Satisfied = len(reasons) == 0
+	res.Reason = strings.Join(reasons, "; ")
+	return res
+}
+
+// Evaluate evaluates every rule in the policy against the experiment, and returns one result per rule in the order of the policy file.
+func (p *Policy) Evaluate(exp *expr.Experiment) *expr.AssertResult {
+	res := &expr.AssertResult{}
+	for i := range p.Rules {
+		res.Results = append(res.Results, p.Rules[i].Evaluate(exp))
+	}
+	return res
+}
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// getExp is a helper function for extracting an experiment object from experiment filenamePrefix
+func getExp(t *testing.T, filenamePrefix string) *expr.Experiment {
+	buf, err := ioutil.ReadFile(utils.CompletePath("../", fmt.Sprintf("testdata/%s.yaml", filenamePrefix)))
+	assert.NoError(t, err)
+	exp := &expr.Experiment{}
+	assert.NoError(t, yaml.Unmarshal(buf, exp))
+	return exp
+}
+
+/* Tests */
+
+func TestFromFile(t *testing.T) {
+	p, err := FromFile(utils.CompletePath("../testdata", "policy1.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(p.Rules))
+	assert.Equal(t, expr.SeverityError, p.Rules[0].Severity)
+	assert.Equal(t, expr.SeverityWarn, p.Rules[2].Severity)
+	assert.Equal(t, 24*time.Hour, p.Rules[3].MaxAge.Duration)
+
+	_, err = FromFile(utils.CompletePath("../testdata", "missing-policy.yaml"))
+	assert.Error(t, err)
+	assert.Equal(t, expr.ErrorKind(""), expr.KindOf(err))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		policy string
+		err    string
+	}{
+		{"rules: []", "invalid policy: no rules found"},
+		{"rules:\n- conditions: [completed]", "invalid policy: rule 0 has no name"},
+		{"rules:\n- name: a\n  conditions: [completed]\n- name: a\n  conditions: [completed]", "invalid policy: duplicate rule name a"},
+		{"rules:\n- name: a", "invalid policy: rule a: no checks found"},
+		{"rules:\n- name: a\n  severity: fatal\n  conditions: [completed]", "invalid policy: rule a: invalid severity fatal; expected error or warn"},
+		{"rules:\n- name: a\n  conditions: [done]", "invalid policy: rule a: Invalid condition: done"},
+		{"rules:\n- name: a\n  minIteration: 3", "invalid policy: json: unknown field \"minIteration\""},
+	} {
+		_, err := Parse([]byte(tc.policy))
+		assert.EqualError(t, err, tc.err)
+		assert.Equal(t, expr.KindUsage, expr.KindOf(err))
+	}
+
+	_, err := Parse([]byte("rules:\n- name: a\n  metrics: ['error-rate < 1']"))
+	assert.Error(t, err)
+	_, err = Parse([]byte("rules:\n- name: a\n  expressions: ['winner']"))
+	assert.Error(t, err)
+	_, err = Parse([]byte("rules:\n- name: a\n  objectives: ['error-rate']"))
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	Now = func() time.Time {
+		return time.Date(2020, 12, 30, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() { Now = time.Now }()
+
+	p, err := FromFile(utils.CompletePath("../testdata", "policy1.yaml"))
+	assert.NoError(t, err)
+
+	res := p.Evaluate(getExp(t, "experiment8"))
+	assert.True(t, res.Satisfied())
+	assert.Equal(t, 6, len(res.Results))
+	assert.True(t, res.Results[0].Satisfied)
+	assert.True(t, res.Results[1].Satisfied)
+	assert.Equal(t, "experiment completed 10 iterations, fewer than 20; version canary has request count 57.714400001, fewer than 100", res.Results[2].Reason)
+	assert.Equal(t, "experiment was created 29h26m26s ago, more than 24h0m0s", res.Results[3].Reason)
+	assert.Equal(t, 2, len(res.Warnings()))
+	assert.True(t, res.Results[4].Skipped)
+	assert.False(t, res.Results[5].Skipped)
+	assert.True(t, res.Results[5].Satisfied)
+
+	res = p.Evaluate(getExp(t, "experiment1"))
+	assert.False(t, res.Satisfied())
+	assert.Equal(t, "canary-won", res.Failures()[0].Name)
+	assert.Equal(t, "experiment has not completed; no winner found in experiment; no winner found in experiment; no version recommended for promotion in experiment", res.Failures()[0].Reason)
+}
@@ -0,0 +1,105 @@
+// Package server serves the status of Iter8 experiments over HTTP, so that portals and dashboards can consume experiment summaries without running iter8ctl.
+// Experiments are served from a Cache, which is kept up to date by watching the cluster.
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	tasks "github.com/iter8-tools/handler/tasks"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var log *logrus.Logger
+
+func init() {
+	log = tasks.GetLogger()
+}
+
+// Cache is an in-memory store of experiments, which is kept up to date by watching the cluster, in the manner of a shared informer.
+// A Cache is safe for concurrent use.
+type Cache struct {
+	mu          sync.RWMutex
+	experiments map[string]*expr.Experiment
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{experiments: map[string]*expr.Experiment{}}
+}
+
+// key returns the namespace/name of an experiment.
+func key(namespace string, name string) string {
+	return namespace + "/" + name
+}
+
+// Start watches experiments in the given namespace, or in all namespaces if namespace is empty, and keeps the cache up to date with changes to them until ctx is done.
+// Start returns once the cache contains the initially listed experiments. Errors encountered later, when the watch of experiments ends unexpectedly, are sent on the returned channel, which is closed when the cache is no longer updated.
+func (c *Cache) Start(ctx context.Context, namespace string) (<-chan error, error) {
+	events, err := expr.WatchExperimentsSynced(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for ev := range events {
+		if ev.Type == expr.Synced {
+			break
+		}
+		c.Apply(ev)
+	}
+	if ctx.Err() != nil {
+		return nil, &expr.Error{Kind: expr.KindTimeout, Err: ctx.Err()}
+	}
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		for ev := range events {
+			c.Apply(ev)
+		}
+		if ctx.Err() == nil {
+			errs <- expr.NewError(expr.KindCluster, "watch of experiments ended")
+		}
+	}()
+	return errs, nil
+}
+
+// Apply updates the cache with an experiment event. Experiments are removed from the cache by Deleted events, which are also sent for experiments deleted while the watch of experiments was restarted; Synced events are ignored.
+func (c *Cache) Apply(ev expr.Event) {
+	if ev.Type == expr.Synced {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := key(ev.Experiment.Namespace, ev.Experiment.Name)
+	if ev.Type == watch.Deleted {
+		delete(c.experiments, k)
+		return
+	}
+	c.experiments[k] = ev.Experiment
+}
+
+// Get returns the experiment with the given namespace and name, or false if there is no such experiment.
+func (c *Cache) Get(namespace string, name string) (*expr.Experiment, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	exp, ok := c.experiments[key(namespace, name)]
+	return exp, ok
+}
+
+// List returns the experiments in the given namespace, or in all namespaces if namespace is empty, sorted by namespace and name.
+func (c *Cache) List(namespace string) []*expr.Experiment {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	exps := []*expr.Experiment{}
+	for _, exp := range c.experiments {
+		if namespace == "" || exp.Namespace == namespace {
+			exps = append(exps, exp)
+		}
+	}
+	sort.Slice(exps, func(i, j int) bool {
+		return key(exps[i].Namespace, exps[i].Name) < key(exps[j].Namespace, exps[j].Name)
+	})
+	return exps
+}
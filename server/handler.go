@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ConditionReport is the outcome of asserting a condition for an experiment.
+type ConditionReport struct {
+	Name      string `json:"name"`
+	Satisfied bool   `json:"satisfied"`
+	// Reason explains why the condition is not satisfied; it is empty for satisfied conditions
+	Reason string `json:"reason,omitempty"`
+}
+
+// AssertReport is the outcome of asserting conditions for an experiment.
+type AssertReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Satisfied is true if every condition is satisfied
+	Satisfied  bool              `json:"satisfied"`
+	Conditions []ConditionReport `json:"conditions"`
+}
+
+// errorReport is the body of error responses.
+type errorReport struct {
+	Error string `json:"error"`
+}
+
+// handler serves experiments in its cache.
+type handler struct {
+	cache *Cache
+}
+
+// NewHandler returns a handler which serves experiments in the cache at the following endpoints. Responses are JSON.
+//  GET /experiments[?namespace=<namespace>]
+//    the describe.Report of every experiment, optionally restricted to a namespace
+//  GET /experiments/<namespace>/<name>
+//    the describe.Report of the experiment
+//  GET /experiments/<namespace>/<name>/assert?condition=<condition>[&condition=<condition>...]
+//    the AssertReport for the given conditions; conditions may also be comma-separated, as with 'iter8ctl assert'
+//  GET /metrics
+//    the metrics exported by Collector, in the Prometheus exposition format
+func NewHandler(c *Cache) http.Handler {
+	h := &handler{cache: c}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(newRegistry(c), promhttp.HandlerOpts{}))
+	mux.HandleFunc("/experiments", h.getOnly(h.list))
+	mux.HandleFunc("/experiments/", h.getOnly(h.experiment))
+	return mux
+}
+
+// getOnly responds to requests other than GET and HEAD with 405 Method Not Allowed, and serves the rest using f.
+func (h *handler) getOnly(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method "+r.Method+" not allowed"))
+			return
+		}
+		f(w, r)
+	}
+}
+
+// list serves the reports of every experiment in the requested namespace.
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	reports := []*describe.Report{}
+	for _, exp := range h.cache.List(r.URL.Query().Get("namespace")) {
+		reports = append(reports, describe.NewReport(exp))
+	}
+	writeJSON(w, http.StatusOK, reports)
+}
+
+// experiment serves the report of, or assertions for, the requested experiment.
+func (h *handler) experiment(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/experiments/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "assert") {
+		writeError(w, http.StatusNotFound, errors.New("no such endpoint: "+r.URL.Path))
+		return
+	}
+	exp, ok := h.cache.Get(parts[0], parts[1])
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("Experiment "+parts[1]+" not found in namespace "+parts[0]))
+		return
+	}
+	if len(parts) == 2 {
+		writeJSON(w, http.StatusOK, describe.NewReport(exp))
+		return
+	}
+
+	var conds []expr.ConditionType
+	for _, param := range r.URL.Query()["condition"] {
+		for _, s := range strings.Split(param, ",") {
+			c, err := expr.ParseConditionType(s)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			conds = append(conds, c)
+		}
+	}
+	if len(conds) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("one or more conditions must be specified"))
+		return
+	}
+	res := exp.Assert(conds)
+	report := AssertReport{
+		Namespace: exp.Namespace,
+		Name:      exp.Name,
+		Satisfied: res.Satisfied(),
+	}
+	for _, cr := range res.Results {
+		report.Conditions = append(report.Conditions, ConditionReport{Name: cr.Name, Satisfied: cr.Satisfied, Reason: cr.Reason})
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Error(err)
+	}
+}
+
+// writeError writes err as the JSON body of a response with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorReport{Error: err.Error()})
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// get requests the path from srv, and decodes the JSON response into v; it returns the status of the response.
+func get(t *testing.T, srv *httptest.Server, path string, v interface{}) int {
+	resp, err := http.Get(srv.URL + path)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+// startServer starts a cache and a test server for it.
+func startServer(t *testing.T, namespace string) (*Cache, *httptest.Server) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	c := NewCache()
+	_, err := c.Start(ctx, namespace)
+	assert.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(c))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func TestHandler(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml", "experiment12.yaml")
+	_, srv := startServer(t, "")
+
+	var reports []describe.Report
+	assert.Equal(t, http.StatusOK, get(t, srv, "/experiments", &reports))
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "kfserving-test", reports[1].Namespace)
+
+	reports = nil
+	assert.Equal(t, http.StatusOK, get(t, srv, "/experiments?namespace=kfserving-test", &reports))
+	assert.Len(t, reports, 1)
+
+	var report describe.Report
+	assert.Equal(t, http.StatusOK, get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1", &report))
+	assert.Equal(t, "canary", report.Winner)
+
+	var ar AssertReport
+	assert.Equal(t, http.StatusOK, get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1/assert?condition=completed,winnerFound", &ar))
+	assert.True(t, ar.Satisfied)
+	assert.Len(t, ar.Conditions, 2)
+
+	var er errorReport
+	assert.Equal(t, http.StatusNotFound, get(t, srv, "/experiments/kfserving-test/missing", &er))
+	assert.Equal(t, "Experiment missing not found in namespace kfserving-test", er.Error)
+	assert.Equal(t, http.StatusNotFound, get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1/winner", &er))
+	assert.Equal(t, http.StatusBadRequest, get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1/assert", &er))
+	assert.Equal(t, http.StatusBadRequest, get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1/assert?condition=done", &er))
+	assert.Equal(t, "Invalid condition: done", er.Error)
+
+	resp, err := http.Post(srv.URL+"/experiments", "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestCacheUpdates(t *testing.T) {
+	rc := testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment5.yaml")
+	_, srv := startServer(t, "kfserving-test")
+
+	var ar AssertReport
+	path := "/experiments/kfserving-test/sklearn-iris-experiment-1/assert?condition=completed"
+	assert.Equal(t, http.StatusOK, get(t, srv, path, &ar))
+	assert.False(t, ar.Satisfied)
+	assert.NotEmpty(t, ar.Conditions[0].Reason)
+
+	e := &v2alpha2.Experiment{}
+	assert.NoError(t, rc.Get(context.Background(), client.ObjectKey{Namespace: "kfserving-test", Name: "sklearn-iris-experiment-1"}, e))
+	iterations := int32(10)
+	e.Status.CompletedIterations = &iterations
+	assert.NoError(t, rc.Status().Update(context.Background(), e))
+	assert.Eventually(t, func() bool {
+		var report describe.Report
+		get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1", &report)
+		return report.CompletedIterations == 10
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, rc.Delete(context.Background(), e))
+	assert.Eventually(t, func() bool {
+		var er errorReport
+		return get(t, srv, "/experiments/kfserving-test/sklearn-iris-experiment-1", &er) == http.StatusNotFound
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// countingClient counts the lists of experiments made using it.
+type countingClient struct {
+	client.WithWatch
+	lists int
+}
+
+func (c *countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.lists++
+	return c.WithWatch.List(ctx, list, opts...)
+}
+
+func TestCacheStart(t *testing.T) {
+	rc := &countingClient{WithWatch: testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml", "experiment12.yaml")}
+	testutil.InstallClient(t, nil, &expr.GetWatchClient, rc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewCache()
+	_, err := c.Start(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, c.List(""), 2)
+	assert.Equal(t, 1, rc.lists)
+
+	c.Apply(expr.Event{Type: expr.Synced})
+	assert.Len(t, c.List(""), 2)
+}
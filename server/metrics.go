@@ -0,0 +1,147 @@
+package server
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"gopkg.in/inf.v0"
+)
+
+// Descriptions of the metrics exported for every experiment in the cache.
+// Every metric is labeled with the namespace and name of its experiment.
+var (
+	completedIterationsDesc = prometheus.NewDesc("iter8_experiment_completed_iterations",
+		"Number of completed iterations of the experiment.",
+		[]string{"namespace", "experiment"}, nil)
+	metricValueDesc = prometheus.NewDesc("iter8_experiment_metric_value",
+		"Most recently observed value of the metric for the version; absent if unavailable.",
+		[]string{"namespace", "experiment", "version", "metric"}, nil)
+	objectiveSatisfiedDesc = prometheus.NewDesc("iter8_experiment_objective_satisfied",
+		"1 if the version satisfies the objective, and 0 if it does not; absent if unavailable. objective_index is the index of the objective in the criteria of the experiment, which distinguishes identical objectives.",
+		[]string{"namespace", "experiment", "version", "objective", "objective_index"}, nil)
+	winnerDesc = prometheus.NewDesc("iter8_experiment_winner",
+		"1 if the version is the winner of the experiment, and 0 otherwise; absent if the winner has not been assessed.",
+		[]string{"namespace", "experiment", "version"}, nil)
+	weightDesc = prometheus.NewDesc("iter8_experiment_weight",
+		"Traffic weight currently applied to the version; absent if unavailable.",
+		[]string{"namespace", "experiment", "version"}, nil)
+)
+
+// Collector is a Prometheus collector which exports the state of every experiment in a cache as gauges, derived when the collector is scraped.
+type Collector struct {
+	cache *Cache
+}
+
+// NewCollector returns a collector for the experiments in the cache.
+func NewCollector(c *Cache) *Collector {
+	return &Collector{cache: c}
+}
+
+// Describe sends the descriptions of the exported metrics to ch.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- completedIterationsDesc
+	ch <- metricValueDesc
+	ch <- objectiveSatisfiedDesc
+	ch <- winnerDesc
+	ch <- weightDesc
+}
+
+// Collect sends the metrics of every experiment in the cache to ch.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, exp := range c.cache.List("") {
+		collectExperiment(exp, ch)
+	}
+}
+
+// newRegistry returns a Prometheus registry with a collector for the experiments in the cache.
+func newRegistry(c *Cache) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewCollector(c))
+	return registry
+}
+
+// WriteMetrics writes the metrics of every experiment in the cache to w in the Prometheus text format; for example, for use with the textfile collector of the node exporter.
+func WriteMetrics(w io.Writer, c *Cache) error {
+	families, err := newRegistry(c).Gather()
+	if err != nil {
+		return err
+	}
+	enc := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectExperiment sends the metrics of the experiment to ch.
+func collectExperiment(exp *expr.Experiment, ch chan<- prometheus.Metric) {
+	ns, name := exp.Namespace, exp.Name
+	// series whose labels collide, such as those of a version listed twice, fail the gathering of every metric in the registry; only the first of them is sent
+	seen := map[string]bool{}
+	gauge := func(desc *prometheus.Desc, value float64, labels ...string) {
+		values := append([]string{ns, name}, labels...)
+		key := desc.String() + "\xff" + strings.Join(values, "\xff")
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		// series with invalid label values are skipped likewise
+		if m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, values...); err == nil {
+			ch <- m
+		}
+	}
+
+	iterations := int32(0)
+	if exp.Status.CompletedIterations != nil {
+		iterations = *exp.Status.CompletedIterations
+	}
+	gauge(completedIterationsDesc, float64(iterations))
+
+	a := exp.GetAnalysis()
+	for _, ma := range a.Metrics {
+		for _, v := range ma.Values {
+			if f, ok := decFloat(v.Value); ok {
+				gauge(metricValueDesc, f, v.Version, ma.Name)
+			}
+		}
+	}
+	for i, oa := range a.Objectives {
+		objective, index := expr.StringifyObjective(oa.Objective), strconv.Itoa(i)
+		for j, s := range oa.Satisfaction {
+			if s != expr.SatisfactionUnknown {
+				gauge(objectiveSatisfiedDesc, boolFloat(s == expr.Satisfied), a.Versions[j], objective, index)
+			}
+		}
+	}
+	for _, version := range a.Versions {
+		if a.WinnerAssessed {
+			gauge(winnerDesc, boolFloat(a.Winner != nil && *a.Winner == version), version)
+		}
+		if w := exp.GetWeight(version); w != nil {
+			gauge(weightDesc, float64(*w), version)
+		}
+	}
+}
+
+// decFloat returns x as a float64, or false if x is nil.
+func decFloat(x *inf.Dec) (float64, bool) {
+	if x == nil {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(x.String(), 64)
+	return f, err == nil
+}
+
+// boolFloat returns 1 if b is true, and 0 otherwise.
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// cacheFromFiles returns a cache containing the given testdata experiments.
+func cacheFromFiles(t *testing.T, files ...string) *Cache {
+	c := NewCache()
+	for _, f := range files {
+		c.Apply(expr.Event{Type: watch.Added, Experiment: &expr.Experiment{Experiment: *testutil.ReadExperiment(t, f)}})
+	}
+	return c
+}
+
+func TestWriteMetrics(t *testing.T) {
+	c := cacheFromFiles(t, "experiment8.yaml")
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMetrics(&buf, c))
+	out := buf.String()
+	labels := `experiment="sklearn-iris-experiment-1",namespace="kfserving-test"`
+	assert.Contains(t, out, "# TYPE iter8_experiment_completed_iterations gauge\n")
+	assert.Contains(t, out, "iter8_experiment_completed_iterations{"+labels+"} 10\n")
+	assert.Contains(t, out, `iter8_experiment_metric_value{experiment="sklearn-iris-experiment-1",metric="mean-latency",namespace="kfserving-test",version="canary"} 229.001070304`+"\n")
+	assert.Contains(t, out, `iter8_experiment_objective_satisfied{experiment="sklearn-iris-experiment-1",namespace="kfserving-test",objective="mean-latency <= 1000.000",objective_index="0",version="default"} 1`+"\n")
+	assert.Contains(t, out, "iter8_experiment_winner{"+labels+`,version="canary"} 1`+"\n")
+	assert.Contains(t, out, "iter8_experiment_winner{"+labels+`,version="default"} 0`+"\n")
+	assert.Contains(t, out, "iter8_experiment_weight{"+labels+`,version="canary"} 85`+"\n")
+	assert.Contains(t, out, "iter8_experiment_weight{"+labels+`,version="default"} 15`+"\n")
+}
+
+func TestWriteMetricsIdenticalObjectives(t *testing.T) {
+	c := cacheFromFiles(t, "experiment8.yaml")
+	exp, _ := c.Get("kfserving-test", "sklearn-iris-experiment-1")
+	exp.Spec.Criteria.Objectives = append(exp.Spec.Criteria.Objectives, exp.Spec.Criteria.Objectives[0])
+	for version, satisfied := range exp.Status.Analysis.VersionAssessments.Data {
+		exp.Status.Analysis.VersionAssessments.Data[version] = append(satisfied, satisfied[0])
+	}
+	index := strconv.Itoa(len(exp.Spec.Criteria.Objectives) - 1)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMetrics(&buf, c))
+	out := buf.String()
+	assert.Contains(t, out, `objective="mean-latency <= 1000.000",objective_index="0",version="default"} 1`+"\n")
+	assert.Contains(t, out, `objective="mean-latency <= 1000.000",objective_index="`+index+`",version="default"} 1`+"\n")
+}
+
+func TestWriteMetricsCollidingLabels(t *testing.T) {
+	c := cacheFromFiles(t, "experiment8.yaml", "experiment12.yaml")
+	exp, _ := c.Get("kfserving-test", "sklearn-iris-experiment-1")
+	exp.Spec.VersionInfo.Candidates = append(exp.Spec.VersionInfo.Candidates, exp.Spec.VersionInfo.Candidates[0])
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMetrics(&buf, c))
+	out := buf.String()
+	labels := `experiment="sklearn-iris-experiment-1",namespace="kfserving-test"`
+	assert.Equal(t, 1, strings.Count(out, "iter8_experiment_winner{"+labels+`,version="canary"} 1`+"\n"))
+	assert.Equal(t, 1, strings.Count(out, "iter8_experiment_weight{"+labels+`,version="canary"} 85`+"\n"))
+	assert.Contains(t, out, `iter8_experiment_winner{experiment="istio-quickstart",namespace="default",version="B"} 1`)
+}
+
+func TestWriteMetricsUnavailable(t *testing.T) {
+	c := cacheFromFiles(t, "experiment1.yaml")
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMetrics(&buf, c))
+	out := buf.String()
+	assert.Contains(t, out, "iter8_experiment_completed_iterations{")
+	assert.NotContains(t, out, "iter8_experiment_winner{")
+	assert.NotContains(t, out, "iter8_experiment_metric_value{")
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	testutil.WithFakeClient(t, &expr.GetClient, &expr.GetWatchClient, "experiment8.yaml", "experiment12.yaml")
+	_, srv := startServer(t, "")
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `iter8_experiment_winner{experiment="istio-quickstart",namespace="default",version="B"} 1`)
+	assert.Contains(t, string(body), `iter8_experiment_weight{experiment="sklearn-iris-experiment-1",namespace="kfserving-test",version="canary"} 85`)
+}
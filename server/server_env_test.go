@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/handler/tasks"
+	"github.com/iter8-tools/iter8ctl/describe"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("serve", func() {
+	// cleanup cluster
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(v2alpha2.GroupVersion.WithKind("experiment"))
+	BeforeEach(func() {
+		k8sClient.DeleteAllOf(context.Background(), u, client.InNamespace("default"))
+	})
+
+	Context("when cluster has an experiment", func() {
+		It("should serve the experiment from the cache", func() {
+			By("creating experiment in cluster")
+			exp, err := (&tasks.Builder{}).FromFile(tasks.CompletePath("../", "testdata/experiment1.yaml")).Build()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Create(context.Background(), &exp.Experiment)).To(Succeed())
+
+			By("starting the cache")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cache := NewCache()
+			_, err = cache.Start(ctx, "")
+			Expect(err).ToNot(HaveOccurred())
+			srv := httptest.NewServer(NewHandler(cache))
+			defer srv.Close()
+
+			By("listing experiments")
+			resp, err := http.Get(srv.URL + "/experiments")
+			Expect(err).ToNot(HaveOccurred())
+			var reports []describe.Report
+			Expect(json.NewDecoder(resp.Body).Decode(&reports)).To(Succeed())
+			resp.Body.Close()
+			Expect(reports).To(HaveLen(1))
+			Expect(reports[0].Name).To(Equal("sklearn-iris-experiment-1"))
+
+			By("deleting the experiment from the cluster")
+			Expect(k8sClient.Delete(context.Background(), &exp.Experiment)).To(Succeed())
+			Eventually(func() int {
+				resp, err := http.Get(srv.URL + "/experiments/default/sklearn-iris-experiment-1")
+				Expect(err).ToNot(HaveOccurred())
+				resp.Body.Close()
+				return resp.StatusCode
+			}, 10).Should(Equal(http.StatusNotFound))
+		})
+	})
+})
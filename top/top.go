@@ -0,0 +1,315 @@
+// Package top implements an interactive, live-updating dashboard of Iter8 experiments, similar to the top command.
+// The dashboard is a Model, which is updated with experiment events and key presses, and rendered as text by View.
+package top
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	"github.com/iter8-tools/iter8ctl/describe"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// MaxHistory is the maximum number of snapshots retained per experiment for metric trends.
+const MaxHistory = 30
+
+// Now returns the time at which snapshots are taken; it is a variable so that it can be mocked in tests.
+var Now = time.Now
+
+// Keys handled by the dashboard.
+const (
+	KeyUp        = "up"
+	KeyDown      = "down"
+	KeyEnter     = "enter"
+	KeyEscape    = "esc"
+	KeyNamespace = "n"
+	KeyStage     = "s"
+	KeyQuit      = "q"
+)
+
+// stages are the stages cycled through by the stage filter.
+var stages = []v2alpha2.ExperimentStageType{
+	v2alpha2.ExperimentStageWaiting,
+	v2alpha2.ExperimentStageInitializing,
+	v2alpha2.ExperimentStageRunning,
+	v2alpha2.ExperimentStageFinishing,
+	v2alpha2.ExperimentStageCompleted,
+}
+
+// Model is the state of the dashboard.
+type Model struct {
+	// experiments maps namespace/name to the latest snapshot of each experiment
+	experiments map[string]*expr.Experiment
+	// history maps namespace/name to successive snapshots of each experiment, oldest first
+	history map[string][]expr.Snapshot
+	// namespace and stage filter the list of experiments; empty strings select all
+	namespace string
+	stage     v2alpha2.ExperimentStageType
+	// selected is the index of the selected experiment in the filtered list
+	selected int
+	// detail is the namespace/name of the experiment being viewed in detail; empty in the list view
+	detail string
+	quit   bool
+}
+
+// NewModel returns a dashboard with no experiments.
+func NewModel() *Model {
+	return &Model{
+		experiments: map[string]*expr.Experiment{},
+		history:     map[string][]expr.Snapshot{},
+	}
+}
+
+// key returns the namespace/name of the experiment.
+func key(exp *expr.Experiment) string {
+	return exp.Namespace + "/" + exp.Name
+}
+
+// Apply updates the dashboard with an experiment event.
+// A snapshot is added to the history of the experiment whenever its status changes.
+func (m *Model) Apply(ev expr.Event) {
+	k := key(ev.Experiment)
+	if ev.Type == watch.Deleted {
+		delete(m.experiments, k)
+		delete(m.history, k)
+		if m.detail == k {
+			m.detail = ""
+		}
+		m.clampSelection()
+		return
+	}
+	h := m.history[k]
+	if s := ev.Experiment.GetSnapshot(Now()); len(h) == 0 || !h[len(h)-1].SameStatus(s) {
+		h = append(h, s)
+		if len(h) > MaxHistory {
+			h = h[len(h)-MaxHistory:]
+		}
+		m.history[k] = h
+	}
+	m.experiments[k] = ev.Experiment
+	// the experiment may have left or entered the filtered list
+	m.clampSelection()
+}
+
+// HandleKey updates the dashboard in response to a key press.
+func (m *Model) HandleKey(k string) {
+	switch k {
+	case KeyQuit:
+		m.quit = true
+	case KeyEscape:
+		m.detail = ""
+	case KeyUp:
+		if m.detail == "" && m.selected > 0 {
+			m.selected--
+		}
+	case KeyDown:
+		if m.detail == "" {
+			m.selected++
+			m.clampSelection()
+		}
+	case KeyEnter:
+		if list := m.filtered(); m.detail == "" && m.selected < len(list) {
+			m.detail = key(list[m.selected])
+		}
+	case KeyNamespace:
+		namespaces := m.namespaces()
+		next := ""
+		for i, ns := range namespaces {
+			if ns == m.namespace {
+				next = namespaces[(i+1)%len(namespaces)]
+			}
+		}
+		m.namespace = next
+		m.selected = 0
+	case KeyStage:
+		next := v2alpha2.ExperimentStageType("")
+		if m.stage == "" {
+			next = stages[0]
+		}
+		for i, s := range stages {
+			if s == m.stage && i+1 < len(stages) {
+				next = stages[i+1]
+			}
+		}
+		m.stage = next
+		m.selected = 0
+	}
+}
+
+// SetNamespace restricts the list of experiments to the given namespace; the empty string selects all namespaces.
+func (m *Model) SetNamespace(namespace string) {
+	m.namespace = namespace
+	m.selected = 0
+}
+
+// Quit indicates if the user has asked to quit the dashboard.
+func (m *Model) Quit() bool {
+	return m.quit
+}
+
+// namespaces returns the empty string, which selects all namespaces, followed by the sorted namespaces of all experiments.
+func (m *Model) namespaces() []string {
+	seen := map[string]bool{}
+	namespaces := []string{""}
+	for _, exp := range m.experiments {
+		if !seen[exp.Namespace] {
+			seen[exp.Namespace] = true
+			namespaces = append(namespaces, exp.Namespace)
+		}
+	}
+	sort.Strings(namespaces[1:])
+	return namespaces
+}
+
+// filtered returns the experiments selected by the namespace and stage filters, sorted by namespace and name.
+func (m *Model) filtered() []*expr.Experiment {
+	var list []*expr.Experiment
+	for _, exp := range m.experiments {
+		if m.namespace != "" && exp.Namespace != m.namespace {
+			continue
+		}
+		if m.stage != "" && stageStr(exp) != string(m.stage) {
+			continue
+		}
+		list = append(list, exp)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return key(list[i]) < key(list[j])
+	})
+	return list
+}
+
+// clampSelection keeps the selection within the filtered list.
+func (m *Model) clampSelection() {
+	if n := len(m.filtered()); m.selected >= n {
+		m.selected = n - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+}
+
+// stageStr returns the stage of the experiment, or the empty string if it is unavailable.
+func stageStr(exp *expr.Experiment) string {
+	if exp.Status.Stage == nil {
+		return ""
+	}
+	return string(*exp.Status.Stage)
+}
+
+// iterationsStr returns the completed iterations of the experiment, and the iterations per loop if specified.
+func iterationsStr(exp *expr.Experiment) string {
+	completed := int32(0)
+	if exp.Status.CompletedIterations != nil {
+		completed = *exp.Status.CompletedIterations
+	}
+	if d := exp.Spec.Duration; d != nil && d.IterationsPerLoop != nil {
+		return fmt.Sprintf("%d/%d", completed, *d.IterationsPerLoop)
+	}
+	return fmt.Sprint(completed)
+}
+
+// winnerStr returns the winning version of the experiment, or "-" if there is none.
+func winnerStr(exp *expr.Experiment) string {
+	if w := exp.GetAnalysis().Winner; w != nil {
+		return *w
+	}
+	return "-"
+}
+
+// View renders the dashboard as lines of text, clipped to the given width and height.
+func (m *Model) View(width, height int) string {
+	var lines []string
+	if exp, ok := m.experiments[m.detail]; ok {
+		lines = m.detailView(exp, width)
+	} else {
+		lines = m.listView()
+	}
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		lines[i] = clip(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// listView renders the filtered list of experiments, with the selected experiment marked by ">".
+func (m *Model) listView() []string {
+	ns, stage := m.namespace, string(m.stage)
+	if ns == "" {
+		ns = "all"
+	}
+	if stage == "" {
+		stage = "all"
+	}
+	list := m.filtered()
+	lines := []string{
+		fmt.Sprintf("Experiments: %d   Namespace: %s   Stage: %s", len(list), ns, stage),
+		"",
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  NAMESPACE\tNAME\tTYPE\tSTAGE\tITERATIONS\tWINNER")
+	for i, exp := range list {
+		marker := " "
+		if i == m.selected {
+			marker = ">"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\t%s\t%s\t%s\n", marker, exp.Namespace, exp.Name,
+			exp.Spec.Strategy.TestingPattern, stageStr(exp), iterationsStr(exp), winnerStr(exp))
+	}
+	tw.Flush()
+	lines = append(lines, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")...)
+	return append(lines, "", "↑/k ↓/j select   enter details   n namespace   s stage   q quit")
+}
+
+// detailView renders the winner, traffic split, objectives, and metrics of an experiment, along with the trend of each metric over the session.
+func (m *Model) detailView(exp *expr.Experiment, width int) []string {
+	a := exp.GetAnalysis()
+	lines := []string{
+		fmt.Sprintf("%s   Stage: %s   Iterations: %s", key(exp), stageStr(exp), iterationsStr(exp)),
+		"",
+		"Winner: " + winnerStr(exp),
+	}
+	if a.Recommended != nil {
+		lines = append(lines, "Recommended for promotion: "+*a.Recommended)
+	}
+	var weights []string
+	for _, version := range a.Versions {
+		if w := exp.GetWeight(version); w != nil {
+			weights = append(weights, fmt.Sprintf("%s %d", version, *w))
+		}
+	}
+	if len(weights) > 0 {
+		lines = append(lines, "Weights: "+strings.Join(weights, " / "))
+	}
+
+	var buf bytes.Buffer
+	err := describe.Builder().
+		WithExperiment(exp).
+		WithSections(describe.SectionObjectives, describe.SectionMetrics).
+		WithHistory(m.history[key(exp)]).
+		WithWidth(width).
+		Render(&buf, describe.FormatText).Error()
+	if err == nil {
+		lines = append(lines, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")...)
+	}
+	return append(lines, "", "esc back   q quit")
+}
+
+// clip truncates line to width runes; lines are not truncated if width is not positive.
+func clip(line string, width int) string {
+	r := []rune(line)
+	if width > 0 && len(r) > width {
+		return string(r[:width])
+	}
+	return line
+}
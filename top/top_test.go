@@ -0,0 +1,158 @@
+package top
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/iter8-tools/etc3/api/v2alpha2"
+	expr "github.com/iter8-tools/iter8ctl/experiment"
+	"github.com/iter8-tools/iter8ctl/utils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// getExp returns the experiment in the given testdata file.
+func getExp(t *testing.T, filenamePrefix string) *expr.Experiment {
+	buf, err := ioutil.ReadFile(utils.CompletePath("../", fmt.Sprintf("testdata/%s.yaml", filenamePrefix)))
+	assert.NoError(t, err)
+	exp := &expr.Experiment{}
+	assert.NoError(t, yaml.Unmarshal(buf, exp))
+	return exp
+}
+
+func TestListView(t *testing.T) {
+	m := NewModel()
+	exp8 := getExp(t, "experiment8")
+	exp12 := getExp(t, "experiment12")
+	m.Apply(expr.Event{Type: watch.Added, Experiment: exp8})
+	m.Apply(expr.Event{Type: watch.Added, Experiment: exp12})
+
+	view := m.View(0, 0)
+	assert.Contains(t, view, "Experiments: 2   Namespace: all   Stage: all")
+	assert.Contains(t, view, "NAMESPACE")
+	lines := strings.Split(view, "\n")
+	assert.True(t, strings.HasPrefix(lines[3], "> "+exp12.Namespace) || strings.HasPrefix(lines[3], "> "+exp8.Namespace))
+
+	// selection stays within the list
+	m.HandleKey(KeyUp)
+	assert.Equal(t, 0, m.selected)
+	m.HandleKey(KeyDown)
+	m.HandleKey(KeyDown)
+	assert.Equal(t, 1, m.selected)
+
+	// cycle namespace filter through each namespace and back to all
+	m.HandleKey(KeyNamespace)
+	assert.NotEqual(t, "", m.namespace)
+	assert.Len(t, m.filtered(), 1)
+	m.HandleKey(KeyNamespace)
+	m.HandleKey(KeyNamespace)
+	assert.Equal(t, "", m.namespace)
+
+	// stage filter
+	m.HandleKey(KeyStage)
+	assert.Equal(t, v2alpha2.ExperimentStageWaiting, m.stage)
+	for range stages {
+		m.HandleKey(KeyStage)
+	}
+	assert.Equal(t, v2alpha2.ExperimentStageType(""), m.stage)
+
+	// height and width clip the view
+	assert.Len(t, strings.Split(m.View(0, 2), "\n"), 2)
+	for _, line := range strings.Split(m.View(10, 0), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 10)
+	}
+
+	m.HandleKey(KeyQuit)
+	assert.True(t, m.Quit())
+}
+
+func TestSelectionFollowsFilteredList(t *testing.T) {
+	m := NewModel()
+	exp := getExp(t, "experiment12")
+	other := &expr.Experiment{Experiment: *exp.Experiment.DeepCopy()}
+	other.Name = "istio-quickstart-2"
+	m.Apply(expr.Event{Type: watch.Added, Experiment: exp})
+	m.Apply(expr.Event{Type: watch.Added, Experiment: other})
+	m.stage = v2alpha2.ExperimentStageCompleted
+	m.HandleKey(KeyDown)
+	assert.Equal(t, 1, m.selected)
+
+	// the selected experiment leaves the stage filter
+	running := &expr.Experiment{Experiment: *other.Experiment.DeepCopy()}
+	stage := v2alpha2.ExperimentStageRunning
+	running.Status.Stage = &stage
+	m.Apply(expr.Event{Type: watch.Modified, Experiment: running})
+	assert.Equal(t, 0, m.selected)
+	m.HandleKey(KeyEnter)
+	assert.Equal(t, key(exp), m.detail)
+
+	// enter is ignored when no experiment is listed
+	m.HandleKey(KeyEscape)
+	exp.Status.Stage = &stage
+	m.Apply(expr.Event{Type: watch.Modified, Experiment: exp})
+	assert.Empty(t, m.filtered())
+	m.HandleKey(KeyEnter)
+	assert.Equal(t, "", m.detail)
+}
+
+func TestDetailView(t *testing.T) {
+	m := NewModel()
+	exp := getExp(t, "experiment8")
+	exp.ResourceVersion = "1"
+	m.Apply(expr.Event{Type: watch.Added, Experiment: exp})
+
+	// an event which does not change the status is not a new snapshot
+	m.Apply(expr.Event{Type: watch.Modified, Experiment: exp})
+	assert.Len(t, m.history[key(exp)], 1)
+
+	updated := &expr.Experiment{Experiment: *exp.Experiment.DeepCopy()}
+	updated.ResourceVersion = "2"
+	q := resource.MustParse("0.5")
+	for metric, vals := range updated.Status.Analysis.AggregatedMetrics.Data {
+		for version, val := range vals.Data {
+			val.Value = &q
+			vals.Data[version] = val
+		}
+		updated.Status.Analysis.AggregatedMetrics.Data[metric] = vals
+	}
+	m.Apply(expr.Event{Type: watch.Modified, Experiment: updated})
+	assert.Len(t, m.history[key(exp)], 2)
+
+	m.HandleKey(KeyEnter)
+	view := m.View(0, 0)
+	assert.Contains(t, view, key(exp))
+	assert.Contains(t, view, "Winner: canary")
+	assert.Contains(t, view, "Weights: default 15 / canary 85")
+	assert.Contains(t, view, "Objective Assessment")
+	assert.Contains(t, view, "Metrics Assessment")
+	assert.Contains(t, view, "0.500 █▁ ↓")
+
+	// navigation keys are ignored in the detail view
+	m.HandleKey(KeyDown)
+	assert.Equal(t, 0, m.selected)
+	m.HandleKey(KeyEscape)
+	assert.Contains(t, m.View(0, 0), "NAMESPACE")
+
+	// deleting the experiment being viewed returns to the list
+	m.HandleKey(KeyEnter)
+	m.Apply(expr.Event{Type: watch.Deleted, Experiment: updated})
+	assert.Contains(t, m.View(0, 0), "Experiments: 0")
+}
+
+func TestHistoryCap(t *testing.T) {
+	m := NewModel()
+	exp := getExp(t, "experiment8")
+	for i := 0; i < MaxHistory+5; i++ {
+		e := &expr.Experiment{Experiment: *exp.Experiment.DeepCopy()}
+		iterations := int32(i)
+		e.Status.CompletedIterations = &iterations
+		m.Apply(expr.Event{Type: watch.Modified, Experiment: e})
+	}
+	h := m.history[key(exp)]
+	assert.Len(t, h, MaxHistory)
+	assert.Equal(t, int32(MaxHistory+4), *h[len(h)-1].CompletedIterations)
+}